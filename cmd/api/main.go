@@ -2,13 +2,22 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
+	"os/signal"
+	"syscall"
 
+	"gv-api/internal/auth"
 	"gv-api/internal/config"
 	"gv-api/internal/database"
+	"gv-api/internal/database/badger"
 	"gv-api/internal/database/sqlc"
 	"gv-api/internal/habits"
+	"gv-api/internal/health"
+	"gv-api/internal/logger"
+	"gv-api/internal/metrics"
+	"gv-api/internal/response"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
@@ -20,29 +29,110 @@ func main() {
 		log.Fatal("Failed to load config", err)
 	}
 
-	db, err := database.New(context.Background(), cfg.DBUrl)
-	if err != nil {
-		log.Fatal(err)
-	}
+	appLog := logger.Setup(cfg)
+
+	var habitRepo habits.Repository
+	var authRepo auth.UserRepository
+	var pinger health.Pinger
+
+	switch cfg.StorageBackend {
+	case config.StorageBackendBadger:
+		badgerDB, err := badger.New(cfg.BadgerPath)
+		if err != nil {
+			appLog.Error("failed to open badger db", "error", err)
+			return
+		}
+		//nolint:errcheck // if the db is closed, the program has already exited
+		defer badgerDB.Close()
+
+		habitRepo = habits.NewBadgerRepository(badgerDB, appLog)
+		authRepo = auth.NewBadgerRepository(badgerDB, appLog)
+		pinger = badger.Pinger{DB: badgerDB}
+	default:
+		db, err := database.New(context.Background(), cfg.DBUrl)
+		if err != nil {
+			appLog.Error("failed to connect to database", "error", err)
+			return
+		}
+		//nolint:errcheck // if the db is closed, the program has already exited
+		defer db.Close()
 
-	//nolint:errcheck // if the db is closed, the program has already exited
-	defer db.Close()
+		habitRepo = habits.NewRepository(db, appLog)
+		authRepo = auth.NewRepository(sqlc.New(db))
+		pinger = db
+		metrics.RegisterPoolStats(db)
+	}
 
-	queries := sqlc.New(db)
-	habitRepo := habits.NewRepository(queries)
-	habitService := habits.NewService(habitRepo)
+	habitService := habits.NewService(habitRepo, appLog)
 	habitHandler := habits.NewHandler(habitService)
 
+	authService := auth.NewService(authRepo, cfg.JWTSecret)
+	authHandler := auth.NewHandler(authService)
+
+	healthHandler := health.NewHandler(pinger)
+
 	r := chi.NewRouter()
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type"},
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"Content-Type", "Authorization"},
 	}))
-	r.Get("/habits", habitHandler.GetDaily)
-	r.Post("/habits", habitHandler.CreateHabit)
-	r.Post("/habits/log", habitHandler.UpsertLog)
+	r.Use(logger.Middleware(appLog))
+	r.Use(metrics.Middleware)
+	r.Use(response.Gzip)
+
+	r.Get("/healthz", healthHandler.Healthz)
+	r.Get("/readyz", healthHandler.Readyz)
+	r.Handle("/metrics", metrics.Handler())
+
+	r.Post("/auth/register", authHandler.Register)
+	r.Post("/auth/login", authHandler.Login)
+	r.Post("/auth/refresh", authHandler.Refresh)
 
-	log.Printf("Starting server on port %s", cfg.Port)
-	log.Fatal(http.ListenAndServe(":"+cfg.Port, r))
+	r.Group(func(r chi.Router) {
+		r.Use(auth.Middleware(cfg.JWTSecret))
+		r.Get("/habits", habitHandler.GetDaily)
+		r.Post("/habits", habitHandler.CreateHabit)
+		r.Post("/habits/log", habitHandler.UpsertLog)
+		r.Post("/habits/log/batch", habitHandler.BatchUpsertLog)
+		r.Get("/habits/summary", habitHandler.GetSummary)
+		r.Get("/habits/{id}/history", habitHandler.GetHistory)
+		r.Get("/habits/{id}/streak", habitHandler.GetStreak)
+	})
+
+	srv := &http.Server{
+		Addr:         ":" + cfg.Port,
+		Handler:      r,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+	}
+
+	useTLS := cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
+
+	go func() {
+		appLog.Info("starting server", "port", cfg.Port, "tls", useTLS)
+		var err error
+		if useTLS {
+			err = srv.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			appLog.Error("server stopped", "error", err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	stop()
+
+	appLog.Info("shutting down server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		appLog.Error("error during server shutdown", "error", err)
+	}
 }
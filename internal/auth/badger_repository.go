@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/dgraph-io/badger/v3"
+
+	"gv-api/internal/logger"
+)
+
+// badgerUser is the JSON encoding stored under the user/{id} key.
+type badgerUser struct {
+	ID           int32  `json:"id"`
+	Email        string `json:"email"`
+	PasswordHash string `json:"password_hash"`
+}
+
+func userKey(id int32) []byte {
+	return []byte(fmt.Sprintf("user/%d", id))
+}
+
+// userEmailKey indexes a user's id by email, so GetUserByEmail doesn't need
+// to scan every user/{id} record.
+func userEmailKey(email string) []byte {
+	return []byte(fmt.Sprintf("user_email/%s", email))
+}
+
+// BadgerRepository is a UserRepository backed by an embedded BadgerDB, for
+// single-user/offline deployments that don't need Postgres.
+type BadgerRepository struct {
+	db  *badger.DB
+	log *slog.Logger
+}
+
+func NewBadgerRepository(db *badger.DB, log *slog.Logger) *BadgerRepository {
+	return &BadgerRepository{db: db, log: log}
+}
+
+func (r *BadgerRepository) CreateUser(ctx context.Context, email, passwordHash string) (User, error) {
+	seq, err := r.db.GetSequence([]byte("user_seq"), 1)
+	if err != nil {
+		r.log.ErrorContext(ctx, "badger create user sequence failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return User{}, err
+	}
+	defer seq.Release()
+
+	next, err := seq.Next()
+	if err != nil {
+		r.log.ErrorContext(ctx, "badger create user sequence failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return User{}, err
+	}
+	id := int32(next) + 1
+
+	data, err := json.Marshal(badgerUser{ID: id, Email: email, PasswordHash: passwordHash})
+	if err != nil {
+		return User{}, err
+	}
+
+	err = r.db.Update(func(txn *badger.Txn) error {
+		if err := txn.Set(userKey(id), data); err != nil {
+			return err
+		}
+		return txn.Set(userEmailKey(email), []byte(strconv.Itoa(int(id))))
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "badger create user failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return User{}, err
+	}
+
+	return User{ID: id, Email: email, PasswordHash: passwordHash}, nil
+}
+
+func (r *BadgerRepository) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	var user badgerUser
+
+	err := r.db.View(func(txn *badger.Txn) error {
+		idItem, err := txn.Get(userEmailKey(email))
+		if err != nil {
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		var id int
+		if err := idItem.Value(func(val []byte) error {
+			id, err = strconv.Atoi(string(val))
+			return err
+		}); err != nil {
+			return err
+		}
+
+		userItem, err := txn.Get(userKey(int32(id)))
+		if err != nil {
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		return userItem.Value(func(val []byte) error {
+			return json.Unmarshal(val, &user)
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			r.log.ErrorContext(ctx, "badger get user by email failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		}
+		return User{}, err
+	}
+
+	return User{ID: user.ID, Email: user.Email, PasswordHash: user.PasswordHash}, nil
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+func newTestBadgerRepository(t *testing.T) *BadgerRepository {
+	t.Helper()
+
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()).WithLogger(nil))
+	if err != nil {
+		t.Fatalf("open badger db: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("close badger db: %v", err)
+		}
+	})
+
+	return NewBadgerRepository(db, testLogger())
+}
+
+func TestBadgerRepository_CreateAndGetUserByEmail(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestBadgerRepository(t)
+
+	created, err := repo.CreateUser(ctx, "a@example.com", "hash")
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	if created.ID == 0 {
+		t.Error("ID = 0, want nonzero")
+	}
+
+	got, err := repo.GetUserByEmail(ctx, "a@example.com")
+	if err != nil {
+		t.Fatalf("GetUserByEmail() error = %v", err)
+	}
+	if got != created {
+		t.Errorf("GetUserByEmail() = %+v, want %+v", got, created)
+	}
+}
+
+func TestBadgerRepository_GetUserByEmail_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestBadgerRepository(t)
+
+	_, err := repo.GetUserByEmail(ctx, "missing@example.com")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestBadgerRepository_CreateUser_AssignsDistinctIDs(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestBadgerRepository(t)
+
+	first, err := repo.CreateUser(ctx, "a@example.com", "hash1")
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	second, err := repo.CreateUser(ctx, "b@example.com", "hash2")
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	if first.ID == second.ID {
+		t.Errorf("both users got ID %d, want distinct IDs", first.ID)
+	}
+}
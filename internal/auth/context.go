@@ -0,0 +1,18 @@
+package auth
+
+import "context"
+
+type ctxKey string
+
+const userIDKey ctxKey = "user_id"
+
+// WithUserID returns a copy of ctx carrying userID.
+func WithUserID(ctx context.Context, userID int32) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserIDFromContext returns the user ID stored in ctx by Middleware, if any.
+func UserIDFromContext(ctx context.Context) (int32, bool) {
+	id, ok := ctx.Value(userIDKey).(int32)
+	return id, ok
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"gv-api/internal/response"
+)
+
+type ServiceInterface interface {
+	Register(ctx context.Context, req RegisterRequest) (RegisterResponse, error)
+	Login(ctx context.Context, req LoginRequest) (TokenResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (TokenResponse, error)
+}
+
+type Handler struct {
+	service ServiceInterface
+}
+
+func NewHandler(s ServiceInterface) *Handler {
+	return &Handler{service: s}
+}
+
+// Register -> POST /auth/register
+func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
+	var req RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid Body")
+		return
+	}
+
+	if req.Email == "" || req.Password == "" {
+		response.Error(w, http.StatusBadRequest, "email and password are required")
+		return
+	}
+
+	user, err := h.service.Register(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, ErrEmailTaken) {
+			response.Error(w, http.StatusConflict, "email already registered")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "Failed to register")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, user)
+}
+
+// Login -> POST /auth/login
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	var req LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid Body")
+		return
+	}
+
+	tokens, err := h.service.Login(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			response.Error(w, http.StatusUnauthorized, "invalid email or password")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "Failed to login")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, tokens)
+}
+
+// Refresh -> POST /auth/refresh
+func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid Body")
+		return
+	}
+
+	tokens, err := h.service.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "invalid or expired refresh token")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, tokens)
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func assertStatus(t testing.TB, got, want int) {
+	t.Helper()
+	if got != want {
+		t.Errorf("got status %d, want %d", got, want)
+	}
+}
+
+func assertBodyContains(t testing.TB, body string, want string) {
+	t.Helper()
+	if !strings.Contains(body, want) {
+		t.Errorf("body %q does not contain %q", body, want)
+	}
+}
+
+type mockService struct {
+	registerFn func(ctx context.Context, req RegisterRequest) (RegisterResponse, error)
+	loginFn    func(ctx context.Context, req LoginRequest) (TokenResponse, error)
+	refreshFn  func(ctx context.Context, refreshToken string) (TokenResponse, error)
+}
+
+func (m *mockService) Register(ctx context.Context, req RegisterRequest) (RegisterResponse, error) {
+	if m.registerFn != nil {
+		return m.registerFn(ctx, req)
+	}
+	return RegisterResponse{}, nil
+}
+
+func (m *mockService) Login(ctx context.Context, req LoginRequest) (TokenResponse, error) {
+	if m.loginFn != nil {
+		return m.loginFn(ctx, req)
+	}
+	return TokenResponse{}, nil
+}
+
+func (m *mockService) Refresh(ctx context.Context, refreshToken string) (TokenResponse, error) {
+	if m.refreshFn != nil {
+		return m.refreshFn(ctx, refreshToken)
+	}
+	return TokenResponse{}, nil
+}
+
+func TestHandler_Register(t *testing.T) {
+	t.Run("returns 201 on success", func(t *testing.T) {
+		mock := &mockService{
+			registerFn: func(ctx context.Context, req RegisterRequest) (RegisterResponse, error) {
+				return RegisterResponse{ID: 1, Email: req.Email}, nil
+			},
+		}
+		handler := NewHandler(mock)
+
+		body := `{"email": "a@example.com", "password": "hunter2"}`
+		req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+
+		handler.Register(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusCreated)
+		assertBodyContains(t, rec.Body.String(), "a@example.com")
+	})
+
+	t.Run("returns 400 for invalid JSON", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader("not json"))
+		rec := httptest.NewRecorder()
+
+		handler.Register(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusBadRequest)
+	})
+
+	t.Run("returns 400 when email or password is missing", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+
+		body := `{"email": "", "password": ""}`
+		req := httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.Register(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusBadRequest)
+	})
+
+	t.Run("returns 409 for a duplicate email", func(t *testing.T) {
+		mock := &mockService{
+			registerFn: func(ctx context.Context, req RegisterRequest) (RegisterResponse, error) {
+				return RegisterResponse{}, ErrEmailTaken
+			},
+		}
+		handler := NewHandler(mock)
+
+		body := `{"email": "a@example.com", "password": "hunter2"}`
+		req := httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.Register(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusConflict)
+	})
+}
+
+func TestHandler_Login(t *testing.T) {
+	t.Run("returns 200 with tokens on success", func(t *testing.T) {
+		mock := &mockService{
+			loginFn: func(ctx context.Context, req LoginRequest) (TokenResponse, error) {
+				return TokenResponse{AccessToken: "access", RefreshToken: "refresh"}, nil
+			},
+		}
+		handler := NewHandler(mock)
+
+		body := `{"email": "a@example.com", "password": "hunter2"}`
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.Login(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusOK)
+		assertBodyContains(t, rec.Body.String(), "access")
+	})
+
+	t.Run("returns 400 for invalid JSON", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader("not json"))
+		rec := httptest.NewRecorder()
+
+		handler.Login(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusBadRequest)
+	})
+
+	t.Run("returns 401 for invalid credentials", func(t *testing.T) {
+		mock := &mockService{
+			loginFn: func(ctx context.Context, req LoginRequest) (TokenResponse, error) {
+				return TokenResponse{}, ErrInvalidCredentials
+			},
+		}
+		handler := NewHandler(mock)
+
+		body := `{"email": "a@example.com", "password": "wrong"}`
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.Login(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusUnauthorized)
+	})
+}
+
+func TestHandler_Refresh(t *testing.T) {
+	t.Run("returns 200 with new tokens on success", func(t *testing.T) {
+		mock := &mockService{
+			refreshFn: func(ctx context.Context, refreshToken string) (TokenResponse, error) {
+				return TokenResponse{AccessToken: "access", RefreshToken: "refresh"}, nil
+			},
+		}
+		handler := NewHandler(mock)
+
+		body := `{"refresh_token": "some-token"}`
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.Refresh(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusOK)
+		assertBodyContains(t, rec.Body.String(), "access")
+	})
+
+	t.Run("returns 400 for invalid JSON", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader("not json"))
+		rec := httptest.NewRecorder()
+
+		handler.Refresh(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusBadRequest)
+	})
+
+	t.Run("returns 401 for an invalid or expired refresh token", func(t *testing.T) {
+		mock := &mockService{
+			refreshFn: func(ctx context.Context, refreshToken string) (TokenResponse, error) {
+				return TokenResponse{}, ErrInvalidToken
+			},
+		}
+		handler := NewHandler(mock)
+
+		body := `{"refresh_token": "bad-token"}`
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.Refresh(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusUnauthorized)
+	})
+}
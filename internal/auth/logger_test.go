@@ -0,0 +1,10 @@
+package auth
+
+import (
+	"io"
+	"log/slog"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMiddleware(t *testing.T) {
+	nextCalled := func(called *bool, gotUserID *int32) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*called = true
+			*gotUserID, _ = UserIDFromContext(r.Context())
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+
+	t.Run("injects the user ID for a valid access token", func(t *testing.T) {
+		token, err := issueToken(testSecret, 7, typeAccess, accessTokenTTL)
+		if err != nil {
+			t.Fatalf("issueToken() error = %v", err)
+		}
+
+		var called bool
+		var gotUserID int32
+		handler := Middleware(testSecret)(nextCalled(&called, &gotUserID))
+
+		req := httptest.NewRequest(http.MethodGet, "/habits", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if !called {
+			t.Fatal("next handler was not called")
+		}
+		if gotUserID != 7 {
+			t.Errorf("got userID %d, want 7", gotUserID)
+		}
+	})
+
+	t.Run("rejects a missing Authorization header", func(t *testing.T) {
+		var called bool
+		var gotUserID int32
+		handler := Middleware(testSecret)(nextCalled(&called, &gotUserID))
+
+		req := httptest.NewRequest(http.MethodGet, "/habits", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if called {
+			t.Error("next handler should not be called")
+		}
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("rejects a garbled Authorization header", func(t *testing.T) {
+		var called bool
+		var gotUserID int32
+		handler := Middleware(testSecret)(nextCalled(&called, &gotUserID))
+
+		req := httptest.NewRequest(http.MethodGet, "/habits", nil)
+		req.Header.Set("Authorization", "not-a-bearer-token")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if called {
+			t.Error("next handler should not be called")
+		}
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("rejects an empty bearer token", func(t *testing.T) {
+		var called bool
+		var gotUserID int32
+		handler := Middleware(testSecret)(nextCalled(&called, &gotUserID))
+
+		req := httptest.NewRequest(http.MethodGet, "/habits", nil)
+		req.Header.Set("Authorization", "Bearer ")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if called {
+			t.Error("next handler should not be called")
+		}
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("rejects a refresh token presented as an access token", func(t *testing.T) {
+		token, err := issueToken(testSecret, 7, typeRefresh, refreshTokenTTL)
+		if err != nil {
+			t.Fatalf("issueToken() error = %v", err)
+		}
+
+		var called bool
+		var gotUserID int32
+		handler := Middleware(testSecret)(nextCalled(&called, &gotUserID))
+
+		req := httptest.NewRequest(http.MethodGet, "/habits", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if called {
+			t.Error("next handler should not be called")
+		}
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("rejects an expired access token", func(t *testing.T) {
+		token, err := issueToken(testSecret, 7, typeAccess, -time.Minute)
+		if err != nil {
+			t.Fatalf("issueToken() error = %v", err)
+		}
+
+		var called bool
+		var gotUserID int32
+		handler := Middleware(testSecret)(nextCalled(&called, &gotUserID))
+
+		req := httptest.NewRequest(http.MethodGet, "/habits", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if called {
+			t.Error("next handler should not be called")
+		}
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("rejects a token signed with the wrong secret", func(t *testing.T) {
+		token, err := issueToken("a-different-secret", 7, typeAccess, accessTokenTTL)
+		if err != nil {
+			t.Fatalf("issueToken() error = %v", err)
+		}
+
+		var called bool
+		var gotUserID int32
+		handler := Middleware(testSecret)(nextCalled(&called, &gotUserID))
+
+		req := httptest.NewRequest(http.MethodGet, "/habits", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if called {
+			t.Error("next handler should not be called")
+		}
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+}
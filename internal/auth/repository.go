@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"gv-api/internal/database/sqlc"
+)
+
+// ErrNotFound is returned when no user matches the given lookup.
+var ErrNotFound = errors.New("user not found")
+
+// User is the auth package's view of a user record, including the password
+// hash needed to verify credentials.
+type User struct {
+	ID           int32
+	Email        string
+	PasswordHash string
+}
+
+type UserRepository interface {
+	CreateUser(ctx context.Context, email, passwordHash string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+}
+
+type PostgresRepository struct {
+	q sqlc.Querier
+}
+
+func NewRepository(q sqlc.Querier) *PostgresRepository {
+	return &PostgresRepository{q: q}
+}
+
+func (r *PostgresRepository) CreateUser(ctx context.Context, email, passwordHash string) (User, error) {
+	u, err := r.q.CreateUser(ctx, sqlc.CreateUserParams{Email: email, PasswordHash: passwordHash})
+	if err != nil {
+		return User{}, err
+	}
+	return User{ID: u.ID, Email: u.Email, PasswordHash: u.PasswordHash}, nil
+}
+
+func (r *PostgresRepository) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	u, err := r.q.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return User{}, ErrNotFound
+		}
+		return User{}, err
+	}
+	return User{ID: u.ID, Email: u.Email, PasswordHash: u.PasswordHash}, nil
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrInvalidCredentials is returned for a login with an unknown email or
+	// a wrong password. The two cases are deliberately indistinguishable to
+	// callers so the API doesn't leak which emails are registered.
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrEmailTaken         = errors.New("email already registered")
+)
+
+type Service struct {
+	repo   UserRepository
+	secret string
+}
+
+func NewService(repo UserRepository, secret string) *Service {
+	return &Service{repo: repo, secret: secret}
+}
+
+func (s *Service) Register(ctx context.Context, req RegisterRequest) (RegisterResponse, error) {
+	if _, err := s.repo.GetUserByEmail(ctx, req.Email); err == nil {
+		return RegisterResponse{}, ErrEmailTaken
+	} else if !errors.Is(err, ErrNotFound) {
+		return RegisterResponse{}, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return RegisterResponse{}, err
+	}
+
+	user, err := s.repo.CreateUser(ctx, req.Email, string(hash))
+	if err != nil {
+		return RegisterResponse{}, err
+	}
+	return RegisterResponse{ID: user.ID, Email: user.Email}, nil
+}
+
+func (s *Service) Login(ctx context.Context, req LoginRequest) (TokenResponse, error) {
+	user, err := s.repo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return TokenResponse{}, ErrInvalidCredentials
+		}
+		return TokenResponse{}, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return TokenResponse{}, ErrInvalidCredentials
+	}
+
+	return s.issueTokens(user.ID)
+}
+
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (TokenResponse, error) {
+	userID, err := parseToken(s.secret, refreshToken, typeRefresh)
+	if err != nil {
+		return TokenResponse{}, ErrInvalidToken
+	}
+	return s.issueTokens(userID)
+}
+
+func (s *Service) issueTokens(userID int32) (TokenResponse, error) {
+	access, err := issueToken(s.secret, userID, typeAccess, accessTokenTTL)
+	if err != nil {
+		return TokenResponse{}, err
+	}
+	refresh, err := issueToken(s.secret, userID, typeRefresh, refreshTokenTTL)
+	if err != nil {
+		return TokenResponse{}, err
+	}
+	return TokenResponse{AccessToken: access, RefreshToken: refresh}, nil
+}
@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testSecret = "test-secret"
+
+type mockUserRepository struct {
+	getUserByEmailFn func(ctx context.Context, email string) (User, error)
+	createUserFn     func(ctx context.Context, email, passwordHash string) (User, error)
+}
+
+func (m *mockUserRepository) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	if m.getUserByEmailFn != nil {
+		return m.getUserByEmailFn(ctx, email)
+	}
+	return User{}, ErrNotFound
+}
+
+func (m *mockUserRepository) CreateUser(ctx context.Context, email, passwordHash string) (User, error) {
+	if m.createUserFn != nil {
+		return m.createUserFn(ctx, email, passwordHash)
+	}
+	return User{}, nil
+}
+
+func TestService_Register(t *testing.T) {
+	t.Run("creates a user when the email is unused", func(t *testing.T) {
+		var gotEmail, gotHash string
+		repo := &mockUserRepository{
+			createUserFn: func(ctx context.Context, email, passwordHash string) (User, error) {
+				gotEmail = email
+				gotHash = passwordHash
+				return User{ID: 1, Email: email, PasswordHash: passwordHash}, nil
+			},
+		}
+		svc := NewService(repo, testSecret)
+
+		resp, err := svc.Register(context.Background(), RegisterRequest{Email: "a@example.com", Password: "hunter2"})
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if resp.ID != 1 || resp.Email != "a@example.com" {
+			t.Errorf("got %+v, want ID 1 and Email a@example.com", resp)
+		}
+		if gotEmail != "a@example.com" {
+			t.Errorf("got email %q, want a@example.com", gotEmail)
+		}
+		if bcrypt.CompareHashAndPassword([]byte(gotHash), []byte("hunter2")) != nil {
+			t.Error("stored hash does not match the submitted password")
+		}
+	})
+
+	t.Run("returns ErrEmailTaken when the email is already registered", func(t *testing.T) {
+		repo := &mockUserRepository{
+			getUserByEmailFn: func(ctx context.Context, email string) (User, error) {
+				return User{ID: 1, Email: email}, nil
+			},
+		}
+		svc := NewService(repo, testSecret)
+
+		_, err := svc.Register(context.Background(), RegisterRequest{Email: "a@example.com", Password: "hunter2"})
+		if !errors.Is(err, ErrEmailTaken) {
+			t.Errorf("got error %v, want ErrEmailTaken", err)
+		}
+	})
+
+	t.Run("propagates an unexpected lookup error", func(t *testing.T) {
+		wantErr := errors.New("db error")
+		repo := &mockUserRepository{
+			getUserByEmailFn: func(ctx context.Context, email string) (User, error) {
+				return User{}, wantErr
+			},
+		}
+		svc := NewService(repo, testSecret)
+
+		_, err := svc.Register(context.Background(), RegisterRequest{Email: "a@example.com", Password: "hunter2"})
+		if !errors.Is(err, wantErr) {
+			t.Errorf("got error %v, want %v", err, wantErr)
+		}
+	})
+}
+
+func TestService_Login(t *testing.T) {
+	t.Run("issues tokens for a correct password", func(t *testing.T) {
+		hash, err := bcrypt.GenerateFromPassword([]byte("hunter2"), bcrypt.DefaultCost)
+		if err != nil {
+			t.Fatalf("bcrypt.GenerateFromPassword() error = %v", err)
+		}
+		repo := &mockUserRepository{
+			getUserByEmailFn: func(ctx context.Context, email string) (User, error) {
+				return User{ID: 7, Email: email, PasswordHash: string(hash)}, nil
+			},
+		}
+		svc := NewService(repo, testSecret)
+
+		tokens, err := svc.Login(context.Background(), LoginRequest{Email: "a@example.com", Password: "hunter2"})
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+			t.Errorf("got %+v, want non-empty tokens", tokens)
+		}
+	})
+
+	t.Run("returns ErrInvalidCredentials for an unknown email", func(t *testing.T) {
+		repo := &mockUserRepository{
+			getUserByEmailFn: func(ctx context.Context, email string) (User, error) {
+				return User{}, ErrNotFound
+			},
+		}
+		svc := NewService(repo, testSecret)
+
+		_, err := svc.Login(context.Background(), LoginRequest{Email: "nobody@example.com", Password: "hunter2"})
+		if !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("got error %v, want ErrInvalidCredentials", err)
+		}
+	})
+
+	t.Run("returns ErrInvalidCredentials for a wrong password", func(t *testing.T) {
+		hash, err := bcrypt.GenerateFromPassword([]byte("hunter2"), bcrypt.DefaultCost)
+		if err != nil {
+			t.Fatalf("bcrypt.GenerateFromPassword() error = %v", err)
+		}
+		repo := &mockUserRepository{
+			getUserByEmailFn: func(ctx context.Context, email string) (User, error) {
+				return User{ID: 7, Email: email, PasswordHash: string(hash)}, nil
+			},
+		}
+		svc := NewService(repo, testSecret)
+
+		_, err = svc.Login(context.Background(), LoginRequest{Email: "a@example.com", Password: "wrong"})
+		if !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("got error %v, want ErrInvalidCredentials", err)
+		}
+	})
+}
+
+func TestService_Refresh(t *testing.T) {
+	t.Run("issues new tokens for a valid refresh token", func(t *testing.T) {
+		svc := NewService(&mockUserRepository{}, testSecret)
+
+		refreshToken, err := issueToken(testSecret, 9, typeRefresh, refreshTokenTTL)
+		if err != nil {
+			t.Fatalf("issueToken() error = %v", err)
+		}
+
+		tokens, err := svc.Refresh(context.Background(), refreshToken)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+			t.Errorf("got %+v, want non-empty tokens", tokens)
+		}
+	})
+
+	t.Run("rejects an access token presented as a refresh token", func(t *testing.T) {
+		svc := NewService(&mockUserRepository{}, testSecret)
+
+		accessToken, err := issueToken(testSecret, 9, typeAccess, accessTokenTTL)
+		if err != nil {
+			t.Fatalf("issueToken() error = %v", err)
+		}
+
+		_, err = svc.Refresh(context.Background(), accessToken)
+		if !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("got error %v, want ErrInvalidToken", err)
+		}
+	})
+
+	t.Run("rejects an expired refresh token", func(t *testing.T) {
+		svc := NewService(&mockUserRepository{}, testSecret)
+
+		expired, err := issueToken(testSecret, 9, typeRefresh, -time.Minute)
+		if err != nil {
+			t.Fatalf("issueToken() error = %v", err)
+		}
+
+		_, err = svc.Refresh(context.Background(), expired)
+		if !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("got error %v, want ErrInvalidToken", err)
+		}
+	})
+
+	t.Run("rejects a garbled token", func(t *testing.T) {
+		svc := NewService(&mockUserRepository{}, testSecret)
+
+		_, err := svc.Refresh(context.Background(), "not-a-jwt")
+		if !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("got error %v, want ErrInvalidToken", err)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned for malformed, expired, or wrong-type tokens.
+var ErrInvalidToken = errors.New("invalid token")
+
+const (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 7 * 24 * time.Hour
+)
+
+type tokenType string
+
+const (
+	typeAccess  tokenType = "access"
+	typeRefresh tokenType = "refresh"
+)
+
+type claims struct {
+	UserID int32     `json:"user_id"`
+	Type   tokenType `json:"type"`
+	jwt.RegisteredClaims
+}
+
+func issueToken(secret string, userID int32, tt tokenType, ttl time.Duration) (string, error) {
+	now := time.Now()
+	c := claims{
+		UserID: userID,
+		Type:   tt,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(secret))
+}
+
+func parseToken(secret, tokenStr string, want tokenType) (int32, error) {
+	var c claims
+	token, err := jwt.ParseWithClaims(tokenStr, &c, func(t *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	if err != nil || !token.Valid {
+		return 0, ErrInvalidToken
+	}
+	if c.Type != want {
+		return 0, ErrInvalidToken
+	}
+	return c.UserID, nil
+}
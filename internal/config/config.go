@@ -1,20 +1,112 @@
 // Package config provides the config
 package config
 
-import "os"
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// StorageBackend selects which habits.Repository implementation the server
+// wires up.
+type StorageBackend string
+
+const (
+	StorageBackendPostgres StorageBackend = "postgres"
+	StorageBackendBadger   StorageBackend = "badger"
+)
 
 type Config struct {
-	DBUrl string
-	Port  string
+	DBUrl           string
+	Port            string
+	LogLevel        slog.Level
+	JWTSecret       string
+	ShutdownTimeout time.Duration
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	TLSCertFile     string
+	TLSKeyFile      string
+	StorageBackend  StorageBackend
+	BadgerPath      string
 }
 
+// Load reads the process environment into a Config, validating every
+// variable before returning. If any variable is invalid, the returned error
+// wraps all of them so startup failures can be diagnosed in one pass rather
+// than one fix-and-rerun cycle at a time.
 func Load() (*Config, error) {
+	var errs []error
+
+	logLevel, err := parseLogLevel(getEnv("LOG_LEVEL", "info"))
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	shutdownTimeout, err := parseDuration("SHUTDOWN_TIMEOUT", "10s")
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	readTimeout, err := parseDuration("READ_TIMEOUT", "15s")
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	writeTimeout, err := parseDuration("WRITE_TIMEOUT", "15s")
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	storageBackend := StorageBackend(getEnv("STORAGE_BACKEND", string(StorageBackendPostgres)))
+	if storageBackend != StorageBackendPostgres && storageBackend != StorageBackendBadger {
+		errs = append(errs, fmt.Errorf("invalid STORAGE_BACKEND: %q", storageBackend))
+	}
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		errs = append(errs, errors.New("JWT_SECRET must not be empty"))
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
 	return &Config{
-		DBUrl: os.Getenv("DATABASE_URL"),
-		Port:  getEnv("PORT", "8080"),
+		DBUrl:           os.Getenv("DATABASE_URL"),
+		Port:            getEnv("PORT", "8080"),
+		LogLevel:        logLevel,
+		JWTSecret:       jwtSecret,
+		ShutdownTimeout: shutdownTimeout,
+		ReadTimeout:     readTimeout,
+		WriteTimeout:    writeTimeout,
+		TLSCertFile:     os.Getenv("TLS_CERT_FILE"),
+		TLSKeyFile:      os.Getenv("TLS_KEY_FILE"),
+		StorageBackend:  storageBackend,
+		BadgerPath:      getEnv("BADGER_PATH", "./data/badger"),
 	}, nil
 }
 
+// parseLogLevel parses level as a slog.Level (e.g. "debug", "INFO",
+// "warn+2"), rejecting anything slog doesn't recognize instead of silently
+// falling back to info.
+func parseLogLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("invalid LOG_LEVEL: %w", err)
+	}
+	return l, nil
+}
+
+func parseDuration(key, fallback string) (time.Duration, error) {
+	d, err := time.ParseDuration(getEnv(key, fallback))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return d, nil
+}
+
 func getEnv(key, fallback string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
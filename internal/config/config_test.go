@@ -0,0 +1,90 @@
+package config
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func setEnv(t *testing.T, key, value string) {
+	t.Helper()
+	t.Setenv(key, value)
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("applies defaults when nothing is set", func(t *testing.T) {
+		setEnv(t, "JWT_SECRET", "test-secret")
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if cfg.LogLevel != slog.LevelInfo {
+			t.Errorf("got LogLevel %v, want %v", cfg.LogLevel, slog.LevelInfo)
+		}
+		if cfg.StorageBackend != StorageBackendPostgres {
+			t.Errorf("got StorageBackend %q, want %q", cfg.StorageBackend, StorageBackendPostgres)
+		}
+	})
+
+	t.Run("parses a typed log level", func(t *testing.T) {
+		setEnv(t, "JWT_SECRET", "test-secret")
+		setEnv(t, "LOG_LEVEL", "debug")
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if cfg.LogLevel != slog.LevelDebug {
+			t.Errorf("got LogLevel %v, want %v", cfg.LogLevel, slog.LevelDebug)
+		}
+	})
+
+	t.Run("parses durations", func(t *testing.T) {
+		setEnv(t, "JWT_SECRET", "test-secret")
+		setEnv(t, "READ_TIMEOUT", "30s")
+		setEnv(t, "WRITE_TIMEOUT", "45s")
+		setEnv(t, "SHUTDOWN_TIMEOUT", "5s")
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if cfg.ReadTimeout.String() != "30s" {
+			t.Errorf("got ReadTimeout %v, want 30s", cfg.ReadTimeout)
+		}
+		if cfg.WriteTimeout.String() != "45s" {
+			t.Errorf("got WriteTimeout %v, want 45s", cfg.WriteTimeout)
+		}
+		if cfg.ShutdownTimeout.String() != "5s" {
+			t.Errorf("got ShutdownTimeout %v, want 5s", cfg.ShutdownTimeout)
+		}
+	})
+
+	t.Run("reports every invalid variable at once", func(t *testing.T) {
+		setEnv(t, "LOG_LEVEL", "not-a-level")
+		setEnv(t, "READ_TIMEOUT", "not-a-duration")
+		setEnv(t, "STORAGE_BACKEND", "not-a-backend")
+		setEnv(t, "JWT_SECRET", "")
+
+		_, err := Load()
+		if err == nil {
+			t.Fatal("got nil, want error")
+		}
+		msg := err.Error()
+		for _, want := range []string{"LOG_LEVEL", "READ_TIMEOUT", "STORAGE_BACKEND", "JWT_SECRET"} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("error %q missing mention of %s", msg, want)
+			}
+		}
+	})
+
+	t.Run("rejects an empty JWT_SECRET", func(t *testing.T) {
+		setEnv(t, "JWT_SECRET", "")
+
+		_, err := Load()
+		if err == nil {
+			t.Fatal("got nil, want error")
+		}
+		if !strings.Contains(err.Error(), "JWT_SECRET") {
+			t.Errorf("error %q missing mention of JWT_SECRET", err)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+// Package badger provides an embedded key-value database for single-user
+// and offline deployments that don't need a standalone Postgres instance.
+package badger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+// New opens (creating if needed) a Badger database at path. The returned
+// *badger.DB is safe for concurrent use and should be closed by the caller
+// when the process shuts down.
+func New(path string) (*badger.DB, error) {
+	opts := badger.DefaultOptions(path).WithLogger(nil)
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open badger db: %w", err)
+	}
+
+	return db, nil
+}
+
+// Pinger adapts a *badger.DB to the health package's Pinger interface, so
+// /readyz can confirm the embedded database is open without depending on
+// Postgres.
+type Pinger struct {
+	DB *badger.DB
+}
+
+func (p Pinger) Ping(ctx context.Context) error {
+	return p.DB.View(func(txn *badger.Txn) error { return nil })
+}
@@ -0,0 +1,501 @@
+package habits
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+
+	"gv-api/internal/logger"
+)
+
+// badgerHabit is the JSON encoding stored under the habit/{id} key.
+type badgerHabit struct {
+	ID          int32   `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	UserID      int32   `json:"user_id"`
+}
+
+// badgerLog is the JSON encoding stored under the log/{habitID}/{date} key.
+type badgerLog struct {
+	Value float32 `json:"value"`
+}
+
+func habitKey(id int32) []byte {
+	return []byte(fmt.Sprintf("habit/%d", id))
+}
+
+func logKey(habitID int32, date time.Time) []byte {
+	return []byte(fmt.Sprintf("log/%d/%s", habitID, date.Format("2006-01-02")))
+}
+
+func logPrefix(habitID int32) []byte {
+	return []byte(fmt.Sprintf("log/%d/", habitID))
+}
+
+// BadgerRepository is a habits.Repository backed by an embedded BadgerDB,
+// for single-user/offline deployments that don't need Postgres. txn is nil
+// outside of a BeginTx-scoped repository, in which case every method opens
+// its own managed transaction.
+type BadgerRepository struct {
+	db  *badger.DB
+	txn *badger.Txn
+	log *slog.Logger
+}
+
+func NewBadgerRepository(db *badger.DB, log *slog.Logger) *BadgerRepository {
+	return &BadgerRepository{db: db, log: log}
+}
+
+func (r *BadgerRepository) view(fn func(txn *badger.Txn) error) error {
+	if r.txn != nil {
+		return fn(r.txn)
+	}
+	return r.db.View(fn)
+}
+
+func (r *BadgerRepository) update(fn func(txn *badger.Txn) error) error {
+	if r.txn != nil {
+		return fn(r.txn)
+	}
+	return r.db.Update(fn)
+}
+
+// badgerTx adapts *badger.Txn's Commit/Discard to the Tx interface.
+type badgerTx struct {
+	txn *badger.Txn
+}
+
+func (t *badgerTx) Commit(ctx context.Context) error {
+	return t.txn.Commit()
+}
+
+func (t *badgerTx) Rollback(ctx context.Context) error {
+	t.txn.Discard()
+	return nil
+}
+
+func (r *BadgerRepository) BeginTx(ctx context.Context) (Tx, Repository, error) {
+	txn := r.db.NewTransaction(true)
+	return &badgerTx{txn: txn}, &BadgerRepository{db: r.db, txn: txn, log: r.log}, nil
+}
+
+func (r *BadgerRepository) getHabit(txn *badger.Txn, id int32) (*badgerHabit, error) {
+	item, err := txn.Get(habitKey(id))
+	if err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	var h badgerHabit
+	if err := item.Value(func(val []byte) error {
+		return json.Unmarshal(val, &h)
+	}); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (r *BadgerRepository) GetHabitsWithLogs(ctx context.Context, date time.Time, userID int32) ([]HabitWithLog, error) {
+	var results []HabitWithLog
+
+	err := r.view(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte("habit/")
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(opts.Prefix); it.ValidForPrefix(opts.Prefix); it.Next() {
+			var h badgerHabit
+			if err := it.Item().Value(func(val []byte) error { return json.Unmarshal(val, &h) }); err != nil {
+				return err
+			}
+			if h.UserID != userID {
+				continue
+			}
+
+			hwl := HabitWithLog{ID: h.ID, Name: h.Name, Description: h.Description}
+
+			logItem, err := txn.Get(logKey(h.ID, date))
+			switch {
+			case err == nil:
+				var lg badgerLog
+				if err := logItem.Value(func(val []byte) error { return json.Unmarshal(val, &lg) }); err != nil {
+					return err
+				}
+				value := lg.Value
+				hwl.LogValue = &value
+			case errors.Is(err, badger.ErrKeyNotFound):
+			default:
+				return err
+			}
+
+			results = append(results, hwl)
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "badger get habits with logs failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return nil, err
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].ID < results[j].ID })
+	return results, nil
+}
+
+func (r *BadgerRepository) UpsertLog(ctx context.Context, habitID int32, date time.Time, value float32, userID int32) error {
+	err := r.update(func(txn *badger.Txn) error {
+		habit, err := r.getHabit(txn, habitID)
+		if err != nil {
+			return err
+		}
+		if habit.UserID != userID {
+			return ErrNotFound
+		}
+
+		data, err := json.Marshal(badgerLog{Value: value})
+		if err != nil {
+			return err
+		}
+		return txn.Set(logKey(habitID, date), data)
+	})
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		r.log.ErrorContext(ctx, "badger upsert log failed", "error", err, "habit_id", habitID, "request_id", logger.RequestIDFromContext(ctx))
+	}
+	return err
+}
+
+func (r *BadgerRepository) UpsertLogs(ctx context.Context, habitIDs []int32, dates []time.Time, values []float32, userID int32) ([]bool, error) {
+	ok := make([]bool, len(habitIDs))
+
+	err := r.update(func(txn *badger.Txn) error {
+		for i := range habitIDs {
+			habit, err := r.getHabit(txn, habitIDs[i])
+			if err != nil {
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				return err
+			}
+			if habit.UserID != userID {
+				continue
+			}
+
+			data, err := json.Marshal(badgerLog{Value: values[i]})
+			if err != nil {
+				return err
+			}
+			if err := txn.Set(logKey(habitIDs[i], dates[i]), data); err != nil {
+				return err
+			}
+			ok[i] = true
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "badger upsert logs failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return nil, err
+	}
+	return ok, nil
+}
+
+func (r *BadgerRepository) CreateHabit(ctx context.Context, name string, description *string, userID int32) (CreateHabitResponse, error) {
+	seq, err := r.db.GetSequence([]byte("habit_seq"), 1)
+	if err != nil {
+		r.log.ErrorContext(ctx, "badger create habit sequence failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return CreateHabitResponse{}, err
+	}
+	defer seq.Release()
+
+	next, err := seq.Next()
+	if err != nil {
+		r.log.ErrorContext(ctx, "badger create habit sequence failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return CreateHabitResponse{}, err
+	}
+	id := int32(next) + 1
+
+	habit := badgerHabit{ID: id, Name: name, Description: description, UserID: userID}
+	data, err := json.Marshal(habit)
+	if err != nil {
+		return CreateHabitResponse{}, err
+	}
+
+	if err := r.update(func(txn *badger.Txn) error {
+		return txn.Set(habitKey(id), data)
+	}); err != nil {
+		r.log.ErrorContext(ctx, "badger create habit failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return CreateHabitResponse{}, err
+	}
+
+	return CreateHabitResponse{ID: id, Name: name, Description: description}, nil
+}
+
+// truncateToGranularity mirrors the Postgres repository's date_trunc call
+// for "day", "week" (ISO weeks starting Monday), and "month".
+func truncateToGranularity(t time.Time, granularity string) time.Time {
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch granularity {
+	case "week":
+		offset := (int(t.Weekday()) + 6) % 7
+		return t.AddDate(0, 0, -offset)
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return t
+	}
+}
+
+func nextBucket(t time.Time, granularity string) time.Time {
+	switch granularity {
+	case "week":
+		return t.AddDate(0, 0, 7)
+	case "month":
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+func (r *BadgerRepository) GetHistory(ctx context.Context, habitID int32, from, to time.Time, granularity string, userID int32) ([]HistoryBucket, error) {
+	logValues := map[string]float32{}
+
+	err := r.view(func(txn *badger.Txn) error {
+		habit, err := r.getHabit(txn, habitID)
+		if err != nil {
+			return err
+		}
+		if habit.UserID != userID {
+			return ErrNotFound
+		}
+
+		prefix := logPrefix(habitID)
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			dateStr := strings.TrimPrefix(string(it.Item().Key()), string(prefix))
+			var lg badgerLog
+			if err := it.Item().Value(func(val []byte) error { return json.Unmarshal(val, &lg) }); err != nil {
+				return err
+			}
+			logValues[dateStr] = lg.Value
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			r.log.ErrorContext(ctx, "badger get habit history failed", "error", err, "habit_id", habitID, "request_id", logger.RequestIDFromContext(ctx))
+		}
+		return nil, err
+	}
+
+	type agg struct {
+		sum   float32
+		min   float32
+		max   float32
+		count int64
+	}
+	aggs := map[time.Time]*agg{}
+	for dateStr, value := range logValues {
+		date, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			return nil, err
+		}
+		if date.Before(from) || date.After(to) {
+			continue
+		}
+		bucket := truncateToGranularity(date, granularity)
+		a, ok := aggs[bucket]
+		if !ok {
+			a = &agg{min: value, max: value}
+			aggs[bucket] = a
+		}
+		a.sum += value
+		if value < a.min {
+			a.min = value
+		}
+		if value > a.max {
+			a.max = value
+		}
+		a.count++
+	}
+
+	var buckets []HistoryBucket
+	for b := truncateToGranularity(from, granularity); !b.After(to); b = nextBucket(b, granularity) {
+		bucket := HistoryBucket{Date: b.Format("2006-01-02")}
+		if a, ok := aggs[b]; ok {
+			bucket.Sum = a.sum
+			bucket.Avg = a.sum / float32(a.count)
+			bucket.Min = a.min
+			bucket.Max = a.max
+			bucket.Count = a.count
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
+
+func (r *BadgerRepository) GetStreak(ctx context.Context, habitID int32, userID int32) (StreakResponse, error) {
+	var dates []time.Time
+
+	err := r.view(func(txn *badger.Txn) error {
+		habit, err := r.getHabit(txn, habitID)
+		if err != nil {
+			return err
+		}
+		if habit.UserID != userID {
+			return ErrNotFound
+		}
+
+		prefix := logPrefix(habitID)
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			dateStr := strings.TrimPrefix(string(it.Item().Key()), string(prefix))
+			date, err := time.Parse("2006-01-02", dateStr)
+			if err != nil {
+				return err
+			}
+			dates = append(dates, date)
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			r.log.ErrorContext(ctx, "badger get habit streak failed", "error", err, "habit_id", habitID, "request_id", logger.RequestIDFromContext(ctx))
+		}
+		return StreakResponse{}, err
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	type run struct {
+		length  int32
+		lastDay time.Time
+	}
+	var runs []run
+	for i := 0; i < len(dates); {
+		j := i
+		for j+1 < len(dates) && dates[j+1].Equal(dates[j].AddDate(0, 0, 1)) {
+			j++
+		}
+		runs = append(runs, run{length: int32(j - i + 1), lastDay: dates[j]})
+		i = j + 1
+	}
+
+	var longest int32
+	for _, rn := range runs {
+		if rn.length > longest {
+			longest = rn.length
+		}
+	}
+
+	today := truncateToGranularity(time.Now(), "day")
+	yesterday := today.AddDate(0, 0, -1)
+
+	var current int32
+	for _, rn := range runs {
+		if rn.lastDay.Equal(today) {
+			current = rn.length
+			break
+		}
+	}
+	if current == 0 {
+		for _, rn := range runs {
+			if rn.lastDay.Equal(yesterday) {
+				current = rn.length
+				break
+			}
+		}
+	}
+
+	return StreakResponse{CurrentStreak: current, LongestStreak: longest}, nil
+}
+
+func (r *BadgerRepository) GetSummary(ctx context.Context, from, to time.Time, totalDays int32, userID int32) ([]HabitSummary, error) {
+	var summaries []HabitSummary
+
+	err := r.view(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte("habit/")
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(opts.Prefix); it.ValidForPrefix(opts.Prefix); it.Next() {
+			var h badgerHabit
+			if err := it.Item().Value(func(val []byte) error { return json.Unmarshal(val, &h) }); err != nil {
+				return err
+			}
+			if h.UserID != userID {
+				continue
+			}
+
+			var sum float32
+			var count int64
+
+			prefix := logPrefix(h.ID)
+			logOpts := badger.DefaultIteratorOptions
+			logOpts.Prefix = prefix
+			logIt := txn.NewIterator(logOpts)
+			for logIt.Seek(prefix); logIt.ValidForPrefix(prefix); logIt.Next() {
+				dateStr := strings.TrimPrefix(string(logIt.Item().Key()), string(prefix))
+				date, err := time.Parse("2006-01-02", dateStr)
+				if err != nil {
+					logIt.Close()
+					return err
+				}
+				if date.Before(from) || date.After(to) {
+					continue
+				}
+				var lg badgerLog
+				if err := logIt.Item().Value(func(val []byte) error { return json.Unmarshal(val, &lg) }); err != nil {
+					logIt.Close()
+					return err
+				}
+				sum += lg.Value
+				count++
+			}
+			logIt.Close()
+
+			var avg float32
+			if count > 0 {
+				avg = sum / float32(count)
+			}
+			days := totalDays
+			if days < 1 {
+				days = 1
+			}
+
+			summaries = append(summaries, HabitSummary{
+				HabitID:        h.ID,
+				Name:           h.Name,
+				Sum:            sum,
+				Avg:            avg,
+				Count:          count,
+				CompletionRate: float32(count) / float32(days),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "badger get habits summary failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return nil, err
+	}
+
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].HabitID < summaries[j].HabitID })
+	return summaries, nil
+}
@@ -0,0 +1,245 @@
+package habits
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+func newTestBadgerRepository(t *testing.T) *BadgerRepository {
+	t.Helper()
+
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()).WithLogger(nil))
+	if err != nil {
+		t.Fatalf("open badger db: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("close badger db: %v", err)
+		}
+	})
+
+	return NewBadgerRepository(db, testLogger())
+}
+
+func date(s string) time.Time {
+	d, _ := time.Parse("2006-01-02", s)
+	return d
+}
+
+func TestBadgerRepository_CreateAndGetHabitsWithLogs(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestBadgerRepository(t)
+
+	habit, err := repo.CreateHabit(ctx, "Read", nil, 1)
+	if err != nil {
+		t.Fatalf("CreateHabit() error = %v", err)
+	}
+	if habit.Name != "Read" {
+		t.Errorf("Name = %q, want %q", habit.Name, "Read")
+	}
+
+	if err := repo.UpsertLog(ctx, habit.ID, date("2024-01-01"), 2.5, 1); err != nil {
+		t.Fatalf("UpsertLog() error = %v", err)
+	}
+
+	results, err := repo.GetHabitsWithLogs(ctx, date("2024-01-01"), 1)
+	if err != nil {
+		t.Fatalf("GetHabitsWithLogs() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d habits, want 1", len(results))
+	}
+	if results[0].LogValue == nil || *results[0].LogValue != 2.5 {
+		t.Errorf("LogValue = %v, want 2.5", results[0].LogValue)
+	}
+
+	results, err = repo.GetHabitsWithLogs(ctx, date("2024-01-02"), 1)
+	if err != nil {
+		t.Fatalf("GetHabitsWithLogs() error = %v", err)
+	}
+	if results[0].LogValue != nil {
+		t.Errorf("LogValue = %v, want nil for an unlogged date", results[0].LogValue)
+	}
+}
+
+func TestBadgerRepository_UpsertLog_WrongUser(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestBadgerRepository(t)
+
+	habit, err := repo.CreateHabit(ctx, "Read", nil, 1)
+	if err != nil {
+		t.Fatalf("CreateHabit() error = %v", err)
+	}
+
+	err = repo.UpsertLog(ctx, habit.ID, date("2024-01-01"), 1, 2)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("UpsertLog() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestBadgerRepository_UpsertLogs(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestBadgerRepository(t)
+
+	mine, err := repo.CreateHabit(ctx, "Read", nil, 1)
+	if err != nil {
+		t.Fatalf("CreateHabit() error = %v", err)
+	}
+	other, err := repo.CreateHabit(ctx, "Run", nil, 2)
+	if err != nil {
+		t.Fatalf("CreateHabit() error = %v", err)
+	}
+
+	ok, err := repo.UpsertLogs(
+		ctx,
+		[]int32{mine.ID, other.ID},
+		[]time.Time{date("2024-01-01"), date("2024-01-01")},
+		[]float32{2.5, 1},
+		1,
+	)
+	if err != nil {
+		t.Fatalf("UpsertLogs() error = %v", err)
+	}
+	if len(ok) != 2 || !ok[0] || ok[1] {
+		t.Errorf("UpsertLogs() = %+v, want [true false]", ok)
+	}
+
+	habits, err := repo.GetHabitsWithLogs(ctx, date("2024-01-01"), 1)
+	if err != nil {
+		t.Fatalf("GetHabitsWithLogs() error = %v", err)
+	}
+	if len(habits) != 1 || habits[0].LogValue == nil || *habits[0].LogValue != 2.5 {
+		t.Errorf("got %+v, want a single habit logged at 2.5", habits)
+	}
+}
+
+func TestBadgerRepository_GetHistory(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestBadgerRepository(t)
+
+	habit, err := repo.CreateHabit(ctx, "Read", nil, 1)
+	if err != nil {
+		t.Fatalf("CreateHabit() error = %v", err)
+	}
+
+	if err := repo.UpsertLog(ctx, habit.ID, date("2024-01-01"), 1, 1); err != nil {
+		t.Fatalf("UpsertLog() error = %v", err)
+	}
+	if err := repo.UpsertLog(ctx, habit.ID, date("2024-01-03"), 3, 1); err != nil {
+		t.Fatalf("UpsertLog() error = %v", err)
+	}
+
+	buckets, err := repo.GetHistory(ctx, habit.ID, date("2024-01-01"), date("2024-01-03"), "day", 1)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	if len(buckets) != 3 {
+		t.Fatalf("got %d buckets, want 3", len(buckets))
+	}
+	if buckets[1].Count != 0 {
+		t.Errorf("buckets[1].Count = %d, want 0 for an unlogged day", buckets[1].Count)
+	}
+	if buckets[2].Sum != 3 {
+		t.Errorf("buckets[2].Sum = %v, want 3", buckets[2].Sum)
+	}
+
+	_, err = repo.GetHistory(ctx, habit.ID, date("2024-01-01"), date("2024-01-03"), "day", 2)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetHistory() error = %v, want ErrNotFound for another user", err)
+	}
+}
+
+func TestBadgerRepository_GetStreak(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestBadgerRepository(t)
+
+	habit, err := repo.CreateHabit(ctx, "Read", nil, 1)
+	if err != nil {
+		t.Fatalf("CreateHabit() error = %v", err)
+	}
+
+	today := time.Now().UTC()
+	yesterday := today.AddDate(0, 0, -1)
+	dayBefore := today.AddDate(0, 0, -2)
+
+	for _, d := range []time.Time{dayBefore, yesterday, today} {
+		if err := repo.UpsertLog(ctx, habit.ID, d, 1, 1); err != nil {
+			t.Fatalf("UpsertLog() error = %v", err)
+		}
+	}
+
+	streak, err := repo.GetStreak(ctx, habit.ID, 1)
+	if err != nil {
+		t.Fatalf("GetStreak() error = %v", err)
+	}
+	if streak.CurrentStreak != 3 {
+		t.Errorf("CurrentStreak = %d, want 3", streak.CurrentStreak)
+	}
+	if streak.LongestStreak != 3 {
+		t.Errorf("LongestStreak = %d, want 3", streak.LongestStreak)
+	}
+}
+
+func TestBadgerRepository_GetSummary(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestBadgerRepository(t)
+
+	habit, err := repo.CreateHabit(ctx, "Read", nil, 1)
+	if err != nil {
+		t.Fatalf("CreateHabit() error = %v", err)
+	}
+	if _, err := repo.CreateHabit(ctx, "Other user's habit", nil, 2); err != nil {
+		t.Fatalf("CreateHabit() error = %v", err)
+	}
+
+	if err := repo.UpsertLog(ctx, habit.ID, date("2024-01-01"), 4, 1); err != nil {
+		t.Fatalf("UpsertLog() error = %v", err)
+	}
+
+	summaries, err := repo.GetSummary(ctx, date("2024-01-01"), date("2024-01-02"), 2, 1)
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("got %d summaries, want 1 (only user 1's habit)", len(summaries))
+	}
+	if summaries[0].Sum != 4 || summaries[0].Count != 1 {
+		t.Errorf("got sum=%v count=%d, want sum=4 count=1", summaries[0].Sum, summaries[0].Count)
+	}
+	if summaries[0].CompletionRate != 0.5 {
+		t.Errorf("CompletionRate = %v, want 0.5", summaries[0].CompletionRate)
+	}
+}
+
+func TestBadgerRepository_BeginTx_Rollback(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestBadgerRepository(t)
+
+	habit, err := repo.CreateHabit(ctx, "Read", nil, 1)
+	if err != nil {
+		t.Fatalf("CreateHabit() error = %v", err)
+	}
+
+	tx, txRepo, err := repo.BeginTx(ctx)
+	if err != nil {
+		t.Fatalf("BeginTx() error = %v", err)
+	}
+	if err := txRepo.UpsertLog(ctx, habit.ID, date("2024-01-01"), 1, 1); err != nil {
+		t.Fatalf("UpsertLog() error = %v", err)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	results, err := repo.GetHabitsWithLogs(ctx, date("2024-01-01"), 1)
+	if err != nil {
+		t.Fatalf("GetHabitsWithLogs() error = %v", err)
+	}
+	if results[0].LogValue != nil {
+		t.Errorf("LogValue = %v, want nil after rollback", results[0].LogValue)
+	}
+}
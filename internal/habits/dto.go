@@ -15,6 +15,16 @@ type LogUpsertRequest struct {
 	Value   float32 `json:"value"`
 }
 
+type BatchLogRequest struct {
+	Logs []LogUpsertRequest `json:"logs"`
+}
+
+type BatchLogResult struct {
+	Index  int    `json:"index"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
 type CreateHabitRequest struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description"`
@@ -25,3 +35,34 @@ type CreateHabitResponse struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description"`
 }
+
+type HistoryBucket struct {
+	Date  string  `json:"date"`
+	Sum   float32 `json:"sum"`
+	Avg   float32 `json:"avg"`
+	Min   float32 `json:"min"`
+	Max   float32 `json:"max"`
+	Count int64   `json:"count"`
+}
+
+// HistoryPoint is a single (date, value) pair produced by projecting a
+// HistoryBucket down to one aggregate, for clients that want a plain time
+// series rather than the full set of aggregates per bucket.
+type HistoryPoint struct {
+	Date  string  `json:"date"`
+	Value float32 `json:"value"`
+}
+
+type StreakResponse struct {
+	CurrentStreak int32 `json:"current_streak"`
+	LongestStreak int32 `json:"longest_streak"`
+}
+
+type HabitSummary struct {
+	HabitID        int32   `json:"habit_id"`
+	Name           string  `json:"name"`
+	Sum            float32 `json:"sum"`
+	Avg            float32 `json:"avg"`
+	Count          int64   `json:"count"`
+	CompletionRate float32 `json:"completion_rate"`
+}
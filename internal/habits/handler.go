@@ -3,15 +3,24 @@ package habits
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/go-chi/chi/v5"
+
+	"gv-api/internal/auth"
 	"gv-api/internal/response"
 )
 
 type ServiceInterface interface {
-	GetDailyView(ctx context.Context, dateStr string) ([]HabitWithLog, error)
-	LogHabit(ctx context.Context, req LogUpsertRequest) error
-	CreateHabit(ctx context.Context, req CreateHabitRequest) (CreateHabitResponse, error)
+	GetDailyView(ctx context.Context, dateStr string, userID int32) ([]HabitWithLog, error)
+	LogHabit(ctx context.Context, req LogUpsertRequest, userID int32) error
+	LogHabitsBatch(ctx context.Context, reqs []LogUpsertRequest, userID int32) ([]BatchLogResult, error)
+	CreateHabit(ctx context.Context, req CreateHabitRequest, userID int32) (CreateHabitResponse, error)
+	GetHistory(ctx context.Context, habitID int32, fromStr, toStr, granularity string, userID int32) ([]HistoryBucket, error)
+	GetStreak(ctx context.Context, habitID int32, userID int32) (StreakResponse, error)
+	GetSummary(ctx context.Context, fromStr, toStr string, userID int32) ([]HabitSummary, error)
 }
 
 type Handler struct {
@@ -24,11 +33,17 @@ func NewHandler(s ServiceInterface) *Handler {
 
 // GetDaily -> GET /habits?date=2023-10-27
 func (h *Handler) GetDaily(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
 	dateParam := r.URL.Query().Get("date")
 
-	habits, err := h.service.GetDailyView(r.Context(), dateParam)
+	habits, err := h.service.GetDailyView(r.Context(), dateParam, userID)
 	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		response.Error(w, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -37,13 +52,23 @@ func (h *Handler) GetDaily(w http.ResponseWriter, r *http.Request) {
 
 // UpsertLog -> POST /habits/log
 func (h *Handler) UpsertLog(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
 	var req LogUpsertRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.Error(w, http.StatusBadRequest, "Invalid Body")
 		return
 	}
 
-	if err := h.service.LogHabit(r.Context(), req); err != nil {
+	if err := h.service.LogHabit(r.Context(), req, userID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "habit not found")
+			return
+		}
 		response.Error(w, http.StatusInternalServerError, "Failed to log")
 		return
 	}
@@ -51,8 +76,138 @@ func (h *Handler) UpsertLog(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
 
+// BatchUpsertLog -> POST /habits/log/batch
+func (h *Handler) BatchUpsertLog(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	var req BatchLogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid Body")
+		return
+	}
+	if len(req.Logs) == 0 {
+		response.Error(w, http.StatusBadRequest, "logs must not be empty")
+		return
+	}
+
+	results, err := h.service.LogHabitsBatch(r.Context(), req.Logs, userID)
+	if err != nil && !errors.Is(err, ErrBatchInvalid) && !errors.Is(err, ErrBatchFailed) {
+		response.Error(w, http.StatusInternalServerError, "Failed to log batch")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{"results": results})
+}
+
+// GetHistory -> GET /habits/{id}/history?from=&to=&granularity=day|week|month&agg=sum|avg|min|max|count
+func (h *Handler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	habitID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid habit id")
+		return
+	}
+
+	granularity := r.URL.Query().Get("granularity")
+	if granularity == "" {
+		granularity = "day"
+	}
+
+	buckets, err := h.service.GetHistory(r.Context(), int32(habitID), r.URL.Query().Get("from"), r.URL.Query().Get("to"), granularity, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotFound):
+			response.Error(w, http.StatusNotFound, "habit not found")
+		case errors.Is(err, ErrInvalidRange):
+			response.Error(w, http.StatusBadRequest, "invalid date range")
+		case errors.Is(err, ErrInvalidGranularity):
+			response.Error(w, http.StatusBadRequest, "granularity must be day, week, or month")
+		default:
+			response.Error(w, http.StatusInternalServerError, "Failed to get history")
+		}
+		return
+	}
+
+	agg := r.URL.Query().Get("agg")
+	if agg == "" {
+		response.JSON(w, http.StatusOK, buckets)
+		return
+	}
+
+	points, err := toHistoryPoints(buckets, agg)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "agg must be sum, avg, min, max, or count")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, points)
+}
+
+// GetStreak -> GET /habits/{id}/streak
+func (h *Handler) GetStreak(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	habitID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid habit id")
+		return
+	}
+
+	streak, err := h.service.GetStreak(r.Context(), int32(habitID), userID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "habit not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "Failed to get streak")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, streak)
+}
+
+// GetSummary -> GET /habits/summary?from=&to=
+func (h *Handler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	summaries, err := h.service.GetSummary(r.Context(), r.URL.Query().Get("from"), r.URL.Query().Get("to"), userID)
+	if err != nil {
+		if errors.Is(err, ErrInvalidRange) {
+			response.Error(w, http.StatusBadRequest, "invalid date range")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "Failed to get summary")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, summaries)
+}
+
 // CreateHabit -> POST /habits
 func (h *Handler) CreateHabit(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
 	var req CreateHabitRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.Error(w, http.StatusBadRequest, "Invalid Body")
@@ -64,7 +219,7 @@ func (h *Handler) CreateHabit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	habit, err := h.service.CreateHabit(r.Context(), req)
+	habit, err := h.service.CreateHabit(r.Context(), req, userID)
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "Failed to create habit")
 		return
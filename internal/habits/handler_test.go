@@ -9,6 +9,10 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"gv-api/internal/auth"
 )
 
 // --- Test Helpers ---
@@ -27,31 +31,73 @@ func assertBodyContains(t testing.TB, body string, want string) {
 	}
 }
 
+func authedRequest(req *http.Request) *http.Request {
+	return req.WithContext(auth.WithUserID(req.Context(), testUserID))
+}
+
+func withURLParam(req *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
 // --- Mocks ---
 
 type mockService struct {
-	logHabitFn     func(ctx context.Context, req LogUpsertRequest) error
-	getDailyViewFn func(ctx context.Context, dateStr string) ([]HabitWithLog, error)
-	createHabitFn  func(ctx context.Context, req CreateHabitRequest) (CreateHabitResponse, error)
+	logHabitFn       func(ctx context.Context, req LogUpsertRequest, userID int32) error
+	logHabitsBatchFn func(ctx context.Context, reqs []LogUpsertRequest, userID int32) ([]BatchLogResult, error)
+	getDailyViewFn   func(ctx context.Context, dateStr string, userID int32) ([]HabitWithLog, error)
+	createHabitFn    func(ctx context.Context, req CreateHabitRequest, userID int32) (CreateHabitResponse, error)
+	getHistoryFn     func(ctx context.Context, habitID int32, fromStr, toStr, granularity string, userID int32) ([]HistoryBucket, error)
+	getStreakFn      func(ctx context.Context, habitID int32, userID int32) (StreakResponse, error)
+	getSummaryFn     func(ctx context.Context, fromStr, toStr string, userID int32) ([]HabitSummary, error)
 }
 
-func (m *mockService) GetDailyView(ctx context.Context, dateStr string) ([]HabitWithLog, error) {
+func (m *mockService) GetDailyView(ctx context.Context, dateStr string, userID int32) ([]HabitWithLog, error) {
 	if m.getDailyViewFn != nil {
-		return m.getDailyViewFn(ctx, dateStr)
+		return m.getDailyViewFn(ctx, dateStr, userID)
 	}
 	return nil, nil
 }
 
-func (m *mockService) LogHabit(ctx context.Context, req LogUpsertRequest) error {
+func (m *mockService) LogHabit(ctx context.Context, req LogUpsertRequest, userID int32) error {
 	if m.logHabitFn != nil {
-		return m.logHabitFn(ctx, req)
+		return m.logHabitFn(ctx, req, userID)
 	}
 	return nil
 }
 
-func (m *mockService) CreateHabit(ctx context.Context, req CreateHabitRequest) (CreateHabitResponse, error) {
+func (m *mockService) LogHabitsBatch(ctx context.Context, reqs []LogUpsertRequest, userID int32) ([]BatchLogResult, error) {
+	if m.logHabitsBatchFn != nil {
+		return m.logHabitsBatchFn(ctx, reqs, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockService) GetHistory(ctx context.Context, habitID int32, fromStr, toStr, granularity string, userID int32) ([]HistoryBucket, error) {
+	if m.getHistoryFn != nil {
+		return m.getHistoryFn(ctx, habitID, fromStr, toStr, granularity, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockService) GetStreak(ctx context.Context, habitID int32, userID int32) (StreakResponse, error) {
+	if m.getStreakFn != nil {
+		return m.getStreakFn(ctx, habitID, userID)
+	}
+	return StreakResponse{}, nil
+}
+
+func (m *mockService) GetSummary(ctx context.Context, fromStr, toStr string, userID int32) ([]HabitSummary, error) {
+	if m.getSummaryFn != nil {
+		return m.getSummaryFn(ctx, fromStr, toStr, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockService) CreateHabit(ctx context.Context, req CreateHabitRequest, userID int32) (CreateHabitResponse, error) {
 	if m.createHabitFn != nil {
-		return m.createHabitFn(ctx, req)
+		return m.createHabitFn(ctx, req, userID)
 	}
 	return CreateHabitResponse{}, nil
 }
@@ -65,16 +111,18 @@ func ptrFloat32(f float32) *float32 {
 func TestHandler_UpsertLog(t *testing.T) {
 	t.Run("delegates to service on valid input", func(t *testing.T) {
 		var got LogUpsertRequest
+		var gotUserID int32
 		mock := &mockService{
-			logHabitFn: func(ctx context.Context, req LogUpsertRequest) error {
+			logHabitFn: func(ctx context.Context, req LogUpsertRequest, userID int32) error {
 				got = req
+				gotUserID = userID
 				return nil
 			},
 		}
 		handler := NewHandler(mock)
 
 		body := `{"habit_id": 1, "date": "2025-01-31", "value": 42.5}`
-		req := httptest.NewRequest(http.MethodPost, "/habits/log", bytes.NewBufferString(body))
+		req := authedRequest(httptest.NewRequest(http.MethodPost, "/habits/log", bytes.NewBufferString(body)))
 		req.Header.Set("Content-Type", "application/json")
 		rec := httptest.NewRecorder()
 
@@ -87,6 +135,21 @@ func TestHandler_UpsertLog(t *testing.T) {
 		if got.Value != 42.5 {
 			t.Errorf("got Value %f, want 42.5", got.Value)
 		}
+		if gotUserID != testUserID {
+			t.Errorf("got userID %d, want %d", gotUserID, testUserID)
+		}
+	})
+
+	t.Run("returns 401 when unauthenticated", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+
+		body := `{"habit_id": 1, "date": "2025-01-31", "value": 42.5}`
+		req := httptest.NewRequest(http.MethodPost, "/habits/log", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.UpsertLog(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusUnauthorized)
 	})
 
 	errorCases := []struct {
@@ -103,12 +166,25 @@ func TestHandler_UpsertLog(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantBody:   "Invalid Body",
 		},
+		{
+			name: "returns 404 when habit not found",
+			body: `{"habit_id": 1, "date": "2025-01-31", "value": 42.5}`,
+			setupMock: func() *mockService {
+				return &mockService{
+					logHabitFn: func(ctx context.Context, req LogUpsertRequest, userID int32) error {
+						return ErrNotFound
+					},
+				}
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "habit not found",
+		},
 		{
 			name: "returns 500 when service fails",
 			body: `{"habit_id": 1, "date": "2025-01-31", "value": 42.5}`,
 			setupMock: func() *mockService {
 				return &mockService{
-					logHabitFn: func(ctx context.Context, req LogUpsertRequest) error {
+					logHabitFn: func(ctx context.Context, req LogUpsertRequest, userID int32) error {
 						return errors.New("db error")
 					},
 				}
@@ -120,7 +196,7 @@ func TestHandler_UpsertLog(t *testing.T) {
 	for _, tc := range errorCases {
 		t.Run(tc.name, func(t *testing.T) {
 			handler := NewHandler(tc.setupMock())
-			req := httptest.NewRequest(http.MethodPost, "/habits/log", strings.NewReader(tc.body))
+			req := authedRequest(httptest.NewRequest(http.MethodPost, "/habits/log", strings.NewReader(tc.body)))
 			rec := httptest.NewRecorder()
 
 			handler.UpsertLog(rec, req)
@@ -131,12 +207,102 @@ func TestHandler_UpsertLog(t *testing.T) {
 	}
 }
 
+func TestHandler_BatchUpsertLog(t *testing.T) {
+	t.Run("delegates to service and returns per-entry results", func(t *testing.T) {
+		var got []LogUpsertRequest
+		mock := &mockService{
+			logHabitsBatchFn: func(ctx context.Context, reqs []LogUpsertRequest, userID int32) ([]BatchLogResult, error) {
+				got = reqs
+				return []BatchLogResult{
+					{Index: 0, Status: "ok"},
+					{Index: 1, Status: "ok"},
+				}, nil
+			},
+		}
+		handler := NewHandler(mock)
+
+		body := `{"logs": [{"habit_id": 1, "date": "2025-01-31", "value": 10}, {"habit_id": 2, "date": "2025-01-31", "value": 20}]}`
+		req := authedRequest(httptest.NewRequest(http.MethodPost, "/habits/log/batch", strings.NewReader(body)))
+		rec := httptest.NewRecorder()
+
+		handler.BatchUpsertLog(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusOK)
+		if len(got) != 2 {
+			t.Fatalf("got %d logs, want 2", len(got))
+		}
+		assertBodyContains(t, rec.Body.String(), `"results"`)
+	})
+
+	t.Run("returns 401 when unauthenticated", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+
+		body := `{"logs": [{"habit_id": 1, "date": "2025-01-31", "value": 10}]}`
+		req := httptest.NewRequest(http.MethodPost, "/habits/log/batch", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.BatchUpsertLog(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("returns 400 for an empty batch", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+
+		body := `{"logs": []}`
+		req := authedRequest(httptest.NewRequest(http.MethodPost, "/habits/log/batch", strings.NewReader(body)))
+		rec := httptest.NewRecorder()
+
+		handler.BatchUpsertLog(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusBadRequest)
+	})
+
+	t.Run("returns 200 with results when the batch is rolled back", func(t *testing.T) {
+		mock := &mockService{
+			logHabitsBatchFn: func(ctx context.Context, reqs []LogUpsertRequest, userID int32) ([]BatchLogResult, error) {
+				return []BatchLogResult{
+					{Index: 0, Status: "rolled_back"},
+					{Index: 1, Status: "error", Error: "habit not found"},
+				}, ErrBatchFailed
+			},
+		}
+		handler := NewHandler(mock)
+
+		body := `{"logs": [{"habit_id": 1, "date": "2025-01-31", "value": 10}, {"habit_id": 2, "date": "2025-01-31", "value": 20}]}`
+		req := authedRequest(httptest.NewRequest(http.MethodPost, "/habits/log/batch", strings.NewReader(body)))
+		rec := httptest.NewRecorder()
+
+		handler.BatchUpsertLog(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusOK)
+		assertBodyContains(t, rec.Body.String(), "rolled_back")
+	})
+
+	t.Run("returns 500 for an unexpected service error", func(t *testing.T) {
+		mock := &mockService{
+			logHabitsBatchFn: func(ctx context.Context, reqs []LogUpsertRequest, userID int32) ([]BatchLogResult, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		handler := NewHandler(mock)
+
+		body := `{"logs": [{"habit_id": 1, "date": "2025-01-31", "value": 10}]}`
+		req := authedRequest(httptest.NewRequest(http.MethodPost, "/habits/log/batch", strings.NewReader(body)))
+		rec := httptest.NewRecorder()
+
+		handler.BatchUpsertLog(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusInternalServerError)
+	})
+}
+
 func TestHandler_GetDaily(t *testing.T) {
 	t.Run("returns habits with logs as JSON", func(t *testing.T) {
 		desc1 := "Daily workout"
 		desc2 := "Read a book"
 		mock := &mockService{
-			getDailyViewFn: func(ctx context.Context, dateStr string) ([]HabitWithLog, error) {
+			getDailyViewFn: func(ctx context.Context, dateStr string, userID int32) ([]HabitWithLog, error) {
 				return []HabitWithLog{
 					{ID: 1, Name: "Exercise", Description: &desc1, LogValue: nil},
 					{ID: 2, Name: "Reading", Description: &desc2, LogValue: ptrFloat32(42.5)},
@@ -145,7 +311,7 @@ func TestHandler_GetDaily(t *testing.T) {
 		}
 		handler := NewHandler(mock)
 
-		req := httptest.NewRequest(http.MethodGet, "/habits?date=2025-01-31", nil)
+		req := authedRequest(httptest.NewRequest(http.MethodGet, "/habits?date=2025-01-31", nil))
 		rec := httptest.NewRecorder()
 
 		handler.GetDaily(rec, req)
@@ -179,15 +345,26 @@ func TestHandler_GetDaily(t *testing.T) {
 		}
 	})
 
+	t.Run("returns 401 when unauthenticated", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+
+		req := httptest.NewRequest(http.MethodGet, "/habits", nil)
+		rec := httptest.NewRecorder()
+
+		handler.GetDaily(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusUnauthorized)
+	})
+
 	t.Run("returns 500 when service fails", func(t *testing.T) {
 		mock := &mockService{
-			getDailyViewFn: func(ctx context.Context, dateStr string) ([]HabitWithLog, error) {
+			getDailyViewFn: func(ctx context.Context, dateStr string, userID int32) ([]HabitWithLog, error) {
 				return nil, errors.New("db error")
 			},
 		}
 		handler := NewHandler(mock)
 
-		req := httptest.NewRequest(http.MethodGet, "/habits", nil)
+		req := authedRequest(httptest.NewRequest(http.MethodGet, "/habits", nil))
 		rec := httptest.NewRecorder()
 
 		handler.GetDaily(rec, req)
@@ -196,18 +373,205 @@ func TestHandler_GetDaily(t *testing.T) {
 	})
 }
 
+func TestHandler_GetHistory(t *testing.T) {
+	t.Run("returns buckets as JSON", func(t *testing.T) {
+		mock := &mockService{
+			getHistoryFn: func(ctx context.Context, habitID int32, fromStr, toStr, granularity string, userID int32) ([]HistoryBucket, error) {
+				return []HistoryBucket{{Date: "2025-01-31", Count: 1}}, nil
+			},
+		}
+		handler := NewHandler(mock)
+
+		req := withURLParam(authedRequest(httptest.NewRequest(http.MethodGet, "/habits/1/history?from=2025-01-01&to=2025-01-31", nil)), "id", "1")
+		rec := httptest.NewRecorder()
+
+		handler.GetHistory(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusOK)
+		assertBodyContains(t, rec.Body.String(), "2025-01-31")
+	})
+
+	t.Run("returns 400 for a non-numeric id", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+
+		req := withURLParam(authedRequest(httptest.NewRequest(http.MethodGet, "/habits/abc/history", nil)), "id", "abc")
+		rec := httptest.NewRecorder()
+
+		handler.GetHistory(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusBadRequest)
+	})
+
+	t.Run("returns 404 when habit not found", func(t *testing.T) {
+		mock := &mockService{
+			getHistoryFn: func(ctx context.Context, habitID int32, fromStr, toStr, granularity string, userID int32) ([]HistoryBucket, error) {
+				return nil, ErrNotFound
+			},
+		}
+		handler := NewHandler(mock)
+
+		req := withURLParam(authedRequest(httptest.NewRequest(http.MethodGet, "/habits/1/history", nil)), "id", "1")
+		rec := httptest.NewRecorder()
+
+		handler.GetHistory(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusNotFound)
+	})
+
+	t.Run("returns 400 for an invalid range", func(t *testing.T) {
+		mock := &mockService{
+			getHistoryFn: func(ctx context.Context, habitID int32, fromStr, toStr, granularity string, userID int32) ([]HistoryBucket, error) {
+				return nil, ErrInvalidRange
+			},
+		}
+		handler := NewHandler(mock)
+
+		req := withURLParam(authedRequest(httptest.NewRequest(http.MethodGet, "/habits/1/history", nil)), "id", "1")
+		rec := httptest.NewRecorder()
+
+		handler.GetHistory(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusBadRequest)
+	})
+
+	t.Run("returns 401 when unauthenticated", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+
+		req := withURLParam(httptest.NewRequest(http.MethodGet, "/habits/1/history", nil), "id", "1")
+		rec := httptest.NewRecorder()
+
+		handler.GetHistory(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("projects buckets to a single agg when requested", func(t *testing.T) {
+		mock := &mockService{
+			getHistoryFn: func(ctx context.Context, habitID int32, fromStr, toStr, granularity string, userID int32) ([]HistoryBucket, error) {
+				return []HistoryBucket{{Date: "2025-01-31", Sum: 4, Max: 3}}, nil
+			},
+		}
+		handler := NewHandler(mock)
+
+		req := withURLParam(authedRequest(httptest.NewRequest(http.MethodGet, "/habits/1/history?agg=max", nil)), "id", "1")
+		rec := httptest.NewRecorder()
+
+		handler.GetHistory(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusOK)
+		assertBodyContains(t, rec.Body.String(), `"value":3`)
+	})
+
+	t.Run("returns 400 for an invalid agg", func(t *testing.T) {
+		mock := &mockService{
+			getHistoryFn: func(ctx context.Context, habitID int32, fromStr, toStr, granularity string, userID int32) ([]HistoryBucket, error) {
+				return []HistoryBucket{{Date: "2025-01-31"}}, nil
+			},
+		}
+		handler := NewHandler(mock)
+
+		req := withURLParam(authedRequest(httptest.NewRequest(http.MethodGet, "/habits/1/history?agg=median", nil)), "id", "1")
+		rec := httptest.NewRecorder()
+
+		handler.GetHistory(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusBadRequest)
+	})
+}
+
+func TestHandler_GetStreak(t *testing.T) {
+	t.Run("returns streak as JSON", func(t *testing.T) {
+		mock := &mockService{
+			getStreakFn: func(ctx context.Context, habitID int32, userID int32) (StreakResponse, error) {
+				return StreakResponse{CurrentStreak: 2, LongestStreak: 5}, nil
+			},
+		}
+		handler := NewHandler(mock)
+
+		req := withURLParam(authedRequest(httptest.NewRequest(http.MethodGet, "/habits/1/streak", nil)), "id", "1")
+		rec := httptest.NewRecorder()
+
+		handler.GetStreak(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusOK)
+		assertBodyContains(t, rec.Body.String(), `"current_streak":2`)
+	})
+
+	t.Run("returns 404 when habit not found", func(t *testing.T) {
+		mock := &mockService{
+			getStreakFn: func(ctx context.Context, habitID int32, userID int32) (StreakResponse, error) {
+				return StreakResponse{}, ErrNotFound
+			},
+		}
+		handler := NewHandler(mock)
+
+		req := withURLParam(authedRequest(httptest.NewRequest(http.MethodGet, "/habits/1/streak", nil)), "id", "1")
+		rec := httptest.NewRecorder()
+
+		handler.GetStreak(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusNotFound)
+	})
+}
+
+func TestHandler_GetSummary(t *testing.T) {
+	t.Run("returns summaries as JSON", func(t *testing.T) {
+		mock := &mockService{
+			getSummaryFn: func(ctx context.Context, fromStr, toStr string, userID int32) ([]HabitSummary, error) {
+				return []HabitSummary{{HabitID: 1, Name: "Exercise"}}, nil
+			},
+		}
+		handler := NewHandler(mock)
+
+		req := authedRequest(httptest.NewRequest(http.MethodGet, "/habits/summary?from=2025-01-01&to=2025-01-31", nil))
+		rec := httptest.NewRecorder()
+
+		handler.GetSummary(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusOK)
+		assertBodyContains(t, rec.Body.String(), "Exercise")
+	})
+
+	t.Run("returns 400 for an invalid range", func(t *testing.T) {
+		mock := &mockService{
+			getSummaryFn: func(ctx context.Context, fromStr, toStr string, userID int32) ([]HabitSummary, error) {
+				return nil, ErrInvalidRange
+			},
+		}
+		handler := NewHandler(mock)
+
+		req := authedRequest(httptest.NewRequest(http.MethodGet, "/habits/summary", nil))
+		rec := httptest.NewRecorder()
+
+		handler.GetSummary(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusBadRequest)
+	})
+
+	t.Run("returns 401 when unauthenticated", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+
+		req := httptest.NewRequest(http.MethodGet, "/habits/summary", nil)
+		rec := httptest.NewRecorder()
+
+		handler.GetSummary(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusUnauthorized)
+	})
+}
+
 func TestHandler_CreateHabit(t *testing.T) {
 	t.Run("returns 201 with created habit", func(t *testing.T) {
 		desc := "Test description"
 		mock := &mockService{
-			createHabitFn: func(ctx context.Context, req CreateHabitRequest) (CreateHabitResponse, error) {
+			createHabitFn: func(ctx context.Context, req CreateHabitRequest, userID int32) (CreateHabitResponse, error) {
 				return CreateHabitResponse{ID: 1, Name: req.Name, Description: req.Description}, nil
 			},
 		}
 		handler := NewHandler(mock)
 
 		body := `{"name": "Exercise", "description": "Test description"}`
-		req := httptest.NewRequest(http.MethodPost, "/habits", strings.NewReader(body))
+		req := authedRequest(httptest.NewRequest(http.MethodPost, "/habits", strings.NewReader(body)))
 		rec := httptest.NewRecorder()
 
 		handler.CreateHabit(rec, req)
@@ -229,6 +593,18 @@ func TestHandler_CreateHabit(t *testing.T) {
 		}
 	})
 
+	t.Run("returns 401 when unauthenticated", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+
+		body := `{"name": "Exercise"}`
+		req := httptest.NewRequest(http.MethodPost, "/habits", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.CreateHabit(rec, req)
+
+		assertStatus(t, rec.Code, http.StatusUnauthorized)
+	})
+
 	errorCases := []struct {
 		name       string
 		body       string
@@ -255,7 +631,7 @@ func TestHandler_CreateHabit(t *testing.T) {
 			body: `{"name": "Exercise"}`,
 			setupMock: func() *mockService {
 				return &mockService{
-					createHabitFn: func(ctx context.Context, req CreateHabitRequest) (CreateHabitResponse, error) {
+					createHabitFn: func(ctx context.Context, req CreateHabitRequest, userID int32) (CreateHabitResponse, error) {
 						return CreateHabitResponse{}, errors.New("db error")
 					},
 				}
@@ -267,7 +643,7 @@ func TestHandler_CreateHabit(t *testing.T) {
 	for _, tc := range errorCases {
 		t.Run(tc.name, func(t *testing.T) {
 			handler := NewHandler(tc.setupMock())
-			req := httptest.NewRequest(http.MethodPost, "/habits", strings.NewReader(tc.body))
+			req := authedRequest(httptest.NewRequest(http.MethodPost, "/habits", strings.NewReader(tc.body)))
 			rec := httptest.NewRecorder()
 
 			handler.CreateHabit(rec, req)
@@ -2,28 +2,69 @@ package habits
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	"gv-api/internal/database/sqlc"
+	"gv-api/internal/logger"
 )
 
+// ErrNotFound is returned when a habit doesn't exist or doesn't belong to the
+// requesting user.
+var ErrNotFound = errors.New("habit not found")
+
+// Tx is the subset of pgx.Tx that callers need to finish a transaction
+// started via Repository.BeginTx. pgx.Tx satisfies this.
+type Tx interface {
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
 type Repository interface {
-	GetHabitsWithLogs(ctx context.Context, date time.Time) ([]HabitWithLog, error)
-	UpsertLog(ctx context.Context, habitID int32, date time.Time, value float32) error
-	CreateHabit(ctx context.Context, name string, description *string) (CreateHabitResponse, error)
+	GetHabitsWithLogs(ctx context.Context, date time.Time, userID int32) ([]HabitWithLog, error)
+	UpsertLog(ctx context.Context, habitID int32, date time.Time, value float32, userID int32) error
+	// UpsertLogs applies a batch of upserts in a single round trip. The
+	// returned slice is aligned with habitIDs/dates/values by index and
+	// reports whether each entry was applied (false if the habit didn't
+	// belong to userID).
+	UpsertLogs(ctx context.Context, habitIDs []int32, dates []time.Time, values []float32, userID int32) ([]bool, error)
+	CreateHabit(ctx context.Context, name string, description *string, userID int32) (CreateHabitResponse, error)
+	// BeginTx starts a transaction and returns a Repository bound to it
+	// alongside the Tx used to commit or roll it back.
+	BeginTx(ctx context.Context) (Tx, Repository, error)
+	GetHistory(ctx context.Context, habitID int32, from, to time.Time, granularity string, userID int32) ([]HistoryBucket, error)
+	GetStreak(ctx context.Context, habitID int32, userID int32) (StreakResponse, error)
+	GetSummary(ctx context.Context, from, to time.Time, totalDays int32, userID int32) ([]HabitSummary, error)
 }
 
 type PostgresRepository struct {
-	q sqlc.Querier
+	pool *pgxpool.Pool
+	q    sqlc.Querier
+	log  *slog.Logger
+}
+
+func NewRepository(pool *pgxpool.Pool, log *slog.Logger) *PostgresRepository {
+	return &PostgresRepository{pool: pool, q: sqlc.New(pool), log: log}
 }
 
-func NewRepository(q sqlc.Querier) *PostgresRepository {
-	return &PostgresRepository{q: q}
+func (r *PostgresRepository) BeginTx(ctx context.Context) (Tx, Repository, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		r.log.ErrorContext(ctx, "begin transaction failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return nil, nil, err
+	}
+	return tx, &PostgresRepository{pool: r.pool, q: sqlc.New(tx), log: r.log}, nil
 }
 
-func (r *PostgresRepository) GetHabitsWithLogs(ctx context.Context, date time.Time) ([]HabitWithLog, error) {
-	rows, err := r.q.GetHabitsWithLogs(ctx, date)
+func (r *PostgresRepository) GetHabitsWithLogs(ctx context.Context, date time.Time, userID int32) ([]HabitWithLog, error) {
+	rows, err := r.q.GetHabitsWithLogs(ctx, sqlc.GetHabitsWithLogsParams{LogDate: date, UserID: userID})
 	if err != nil {
+		r.log.ErrorContext(ctx, "query habits with logs failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
 		return nil, err
 	}
 
@@ -41,21 +82,56 @@ func (r *PostgresRepository) GetHabitsWithLogs(ctx context.Context, date time.Ti
 	return results, nil
 }
 
-func (r *PostgresRepository) UpsertLog(ctx context.Context, habitID int32, date time.Time, value float32) error {
+func (r *PostgresRepository) UpsertLog(ctx context.Context, habitID int32, date time.Time, value float32, userID int32) error {
 	params := sqlc.UpsertLogParams{
 		HabitID: habitID,
 		LogDate: date,
 		Value:   value,
+		UserID:  userID,
+	}
+	rowsAffected, err := r.q.UpsertLog(ctx, params)
+	if err != nil {
+		r.log.ErrorContext(ctx, "upsert log query failed", "error", err, "habit_id", habitID, "request_id", logger.RequestIDFromContext(ctx))
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) UpsertLogs(ctx context.Context, habitIDs []int32, dates []time.Time, values []float32, userID int32) ([]bool, error) {
+	rows, err := r.q.UpsertLogs(ctx, sqlc.UpsertLogsParams{
+		HabitIds: habitIDs,
+		LogDates: dates,
+		Values:   values,
+		UserID:   userID,
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "upsert logs query failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return nil, err
+	}
+
+	applied := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		applied[fmt.Sprintf("%d|%s", row.HabitID, row.LogDate.Format("2006-01-02"))] = true
+	}
+
+	ok := make([]bool, len(habitIDs))
+	for i := range habitIDs {
+		ok[i] = applied[fmt.Sprintf("%d|%s", habitIDs[i], dates[i].Format("2006-01-02"))]
 	}
-	return r.q.UpsertLog(ctx, params)
+	return ok, nil
 }
 
-func (r *PostgresRepository) CreateHabit(ctx context.Context, name string, description *string) (CreateHabitResponse, error) {
+func (r *PostgresRepository) CreateHabit(ctx context.Context, name string, description *string, userID int32) (CreateHabitResponse, error) {
 	habit, err := r.q.CreateHabit(ctx, sqlc.CreateHabitParams{
 		Name:        name,
 		Description: description,
+		UserID:      userID,
 	})
 	if err != nil {
+		r.log.ErrorContext(ctx, "create habit query failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
 		return CreateHabitResponse{}, err
 	}
 	return CreateHabitResponse{
@@ -64,3 +140,74 @@ func (r *PostgresRepository) CreateHabit(ctx context.Context, name string, descr
 		Description: habit.Description,
 	}, nil
 }
+
+func (r *PostgresRepository) GetHistory(ctx context.Context, habitID int32, from, to time.Time, granularity string, userID int32) ([]HistoryBucket, error) {
+	rows, err := r.q.GetHabitHistory(ctx, sqlc.GetHabitHistoryParams{
+		From:        from,
+		To:          to,
+		Granularity: granularity,
+		HabitID:     habitID,
+		UserID:      userID,
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "get habit history query failed", "error", err, "habit_id", habitID, "request_id", logger.RequestIDFromContext(ctx))
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrNotFound
+	}
+
+	buckets := make([]HistoryBucket, len(rows))
+	for i, row := range rows {
+		buckets[i] = HistoryBucket{
+			Date:  row.Bucket.Format("2006-01-02"),
+			Sum:   row.Sum,
+			Avg:   row.Avg,
+			Min:   row.Min,
+			Max:   row.Max,
+			Count: row.Count,
+		}
+	}
+	return buckets, nil
+}
+
+func (r *PostgresRepository) GetStreak(ctx context.Context, habitID int32, userID int32) (StreakResponse, error) {
+	row, err := r.q.GetHabitStreak(ctx, sqlc.GetHabitStreakParams{HabitID: habitID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return StreakResponse{}, ErrNotFound
+		}
+		r.log.ErrorContext(ctx, "get habit streak query failed", "error", err, "habit_id", habitID, "request_id", logger.RequestIDFromContext(ctx))
+		return StreakResponse{}, err
+	}
+	return StreakResponse{
+		CurrentStreak: row.CurrentStreak,
+		LongestStreak: row.LongestStreak,
+	}, nil
+}
+
+func (r *PostgresRepository) GetSummary(ctx context.Context, from, to time.Time, totalDays int32, userID int32) ([]HabitSummary, error) {
+	rows, err := r.q.GetHabitsSummary(ctx, sqlc.GetHabitsSummaryParams{
+		From:      from,
+		To:        to,
+		TotalDays: totalDays,
+		UserID:    userID,
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "get habits summary query failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return nil, err
+	}
+
+	summaries := make([]HabitSummary, len(rows))
+	for i, row := range rows {
+		summaries[i] = HabitSummary{
+			HabitID:        row.ID,
+			Name:           row.Name,
+			Sum:            row.Sum,
+			Avg:            row.Avg,
+			Count:          row.Count,
+			CompletionRate: row.CompletionRate,
+		}
+	}
+	return summaries, nil
+}
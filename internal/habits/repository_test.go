@@ -6,27 +6,52 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+
 	"gv-api/internal/database/sqlc"
 )
 
 type mockQuerier struct {
-	getHabitsWithLogsFn func(ctx context.Context, logDate time.Time) ([]sqlc.GetHabitsWithLogsRow, error)
-	upsertLogFn         func(ctx context.Context, arg sqlc.UpsertLogParams) error
+	getHabitsWithLogsFn func(ctx context.Context, arg sqlc.GetHabitsWithLogsParams) ([]sqlc.GetHabitsWithLogsRow, error)
+	upsertLogFn         func(ctx context.Context, arg sqlc.UpsertLogParams) (int64, error)
+	upsertLogsFn        func(ctx context.Context, arg sqlc.UpsertLogsParams) ([]sqlc.UpsertLogsRow, error)
 	createHabitFn       func(ctx context.Context, arg sqlc.CreateHabitParams) (sqlc.Habit, error)
+	getHabitHistoryFn   func(ctx context.Context, arg sqlc.GetHabitHistoryParams) ([]sqlc.GetHabitHistoryRow, error)
+	getHabitStreakFn    func(ctx context.Context, arg sqlc.GetHabitStreakParams) (sqlc.GetHabitStreakRow, error)
+	getHabitsSummaryFn  func(ctx context.Context, arg sqlc.GetHabitsSummaryParams) ([]sqlc.GetHabitsSummaryRow, error)
+}
+
+func (m *mockQuerier) CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error) {
+	return sqlc.User{}, nil
+}
+
+func (m *mockQuerier) GetUserByEmail(ctx context.Context, email string) (sqlc.User, error) {
+	return sqlc.User{}, nil
+}
+
+func (m *mockQuerier) GetUserByID(ctx context.Context, id int32) (sqlc.User, error) {
+	return sqlc.User{}, nil
 }
 
-func (m *mockQuerier) GetHabitsWithLogs(ctx context.Context, logDate time.Time) ([]sqlc.GetHabitsWithLogsRow, error) {
+func (m *mockQuerier) GetHabitsWithLogs(ctx context.Context, arg sqlc.GetHabitsWithLogsParams) ([]sqlc.GetHabitsWithLogsRow, error) {
 	if m.getHabitsWithLogsFn != nil {
-		return m.getHabitsWithLogsFn(ctx, logDate)
+		return m.getHabitsWithLogsFn(ctx, arg)
 	}
 	return nil, nil
 }
 
-func (m *mockQuerier) UpsertLog(ctx context.Context, arg sqlc.UpsertLogParams) error {
+func (m *mockQuerier) UpsertLog(ctx context.Context, arg sqlc.UpsertLogParams) (int64, error) {
 	if m.upsertLogFn != nil {
 		return m.upsertLogFn(ctx, arg)
 	}
-	return nil
+	return 1, nil
+}
+
+func (m *mockQuerier) UpsertLogs(ctx context.Context, arg sqlc.UpsertLogsParams) ([]sqlc.UpsertLogsRow, error) {
+	if m.upsertLogsFn != nil {
+		return m.upsertLogsFn(ctx, arg)
+	}
+	return nil, nil
 }
 
 func (m *mockQuerier) CreateHabit(ctx context.Context, arg sqlc.CreateHabitParams) (sqlc.Habit, error) {
@@ -36,23 +61,44 @@ func (m *mockQuerier) CreateHabit(ctx context.Context, arg sqlc.CreateHabitParam
 	return sqlc.Habit{}, nil
 }
 
+func (m *mockQuerier) GetHabitHistory(ctx context.Context, arg sqlc.GetHabitHistoryParams) ([]sqlc.GetHabitHistoryRow, error) {
+	if m.getHabitHistoryFn != nil {
+		return m.getHabitHistoryFn(ctx, arg)
+	}
+	return nil, nil
+}
+
+func (m *mockQuerier) GetHabitStreak(ctx context.Context, arg sqlc.GetHabitStreakParams) (sqlc.GetHabitStreakRow, error) {
+	if m.getHabitStreakFn != nil {
+		return m.getHabitStreakFn(ctx, arg)
+	}
+	return sqlc.GetHabitStreakRow{}, nil
+}
+
+func (m *mockQuerier) GetHabitsSummary(ctx context.Context, arg sqlc.GetHabitsSummaryParams) ([]sqlc.GetHabitsSummaryRow, error) {
+	if m.getHabitsSummaryFn != nil {
+		return m.getHabitsSummaryFn(ctx, arg)
+	}
+	return nil, nil
+}
+
 func TestRepository_GetHabitsWithLogs(t *testing.T) {
 	t.Run("maps rows to domain types", func(t *testing.T) {
 		desc := "Daily workout"
 		val := float32(42.5)
 
 		mock := &mockQuerier{
-			getHabitsWithLogsFn: func(ctx context.Context, logDate time.Time) ([]sqlc.GetHabitsWithLogsRow, error) {
+			getHabitsWithLogsFn: func(ctx context.Context, arg sqlc.GetHabitsWithLogsParams) ([]sqlc.GetHabitsWithLogsRow, error) {
 				return []sqlc.GetHabitsWithLogsRow{
 					{ID: 1, Name: "Exercise", Description: &desc, Value: &val},
 					{ID: 2, Name: "Reading", Description: nil, Value: nil},
 				}, nil
 			},
 		}
-		repo := NewRepository(mock)
+		repo := &PostgresRepository{q: mock, log: testLogger()}
 
 		date := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
-		got, err := repo.GetHabitsWithLogs(context.Background(), date)
+		got, err := repo.GetHabitsWithLogs(context.Background(), date, testUserID)
 		if err != nil {
 			t.Fatalf("got error %v, want nil", err)
 		}
@@ -73,15 +119,34 @@ func TestRepository_GetHabitsWithLogs(t *testing.T) {
 		}
 	})
 
+	t.Run("scopes query to the requesting user", func(t *testing.T) {
+		var gotUserID int32
+		mock := &mockQuerier{
+			getHabitsWithLogsFn: func(ctx context.Context, arg sqlc.GetHabitsWithLogsParams) ([]sqlc.GetHabitsWithLogsRow, error) {
+				gotUserID = arg.UserID
+				return nil, nil
+			},
+		}
+		repo := &PostgresRepository{q: mock, log: testLogger()}
+
+		_, err := repo.GetHabitsWithLogs(context.Background(), time.Now(), testUserID)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if gotUserID != testUserID {
+			t.Errorf("got userID %d, want %d", gotUserID, testUserID)
+		}
+	})
+
 	t.Run("returns error from querier", func(t *testing.T) {
 		mock := &mockQuerier{
-			getHabitsWithLogsFn: func(ctx context.Context, logDate time.Time) ([]sqlc.GetHabitsWithLogsRow, error) {
+			getHabitsWithLogsFn: func(ctx context.Context, arg sqlc.GetHabitsWithLogsParams) ([]sqlc.GetHabitsWithLogsRow, error) {
 				return nil, errors.New("db error")
 			},
 		}
-		repo := NewRepository(mock)
+		repo := &PostgresRepository{q: mock, log: testLogger()}
 
-		_, err := repo.GetHabitsWithLogs(context.Background(), time.Now())
+		_, err := repo.GetHabitsWithLogs(context.Background(), time.Now(), testUserID)
 		if err == nil {
 			t.Fatal("got nil, want error")
 		}
@@ -93,15 +158,15 @@ func TestRepository_UpsertLog(t *testing.T) {
 		var got sqlc.UpsertLogParams
 
 		mock := &mockQuerier{
-			upsertLogFn: func(ctx context.Context, arg sqlc.UpsertLogParams) error {
+			upsertLogFn: func(ctx context.Context, arg sqlc.UpsertLogParams) (int64, error) {
 				got = arg
-				return nil
+				return 1, nil
 			},
 		}
-		repo := NewRepository(mock)
+		repo := &PostgresRepository{q: mock, log: testLogger()}
 
 		wantDate := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
-		err := repo.UpsertLog(context.Background(), 5, wantDate, 100.0)
+		err := repo.UpsertLog(context.Background(), 5, wantDate, 100.0, testUserID)
 		if err != nil {
 			t.Fatalf("got error %v, want nil", err)
 		}
@@ -114,17 +179,74 @@ func TestRepository_UpsertLog(t *testing.T) {
 		if got.Value != 100.0 {
 			t.Errorf("got value %f, want 100.0", got.Value)
 		}
+		if got.UserID != testUserID {
+			t.Errorf("got userID %d, want %d", got.UserID, testUserID)
+		}
+	})
+
+	t.Run("returns ErrNotFound when no rows are affected", func(t *testing.T) {
+		mock := &mockQuerier{
+			upsertLogFn: func(ctx context.Context, arg sqlc.UpsertLogParams) (int64, error) {
+				return 0, nil
+			},
+		}
+		repo := &PostgresRepository{q: mock, log: testLogger()}
+
+		err := repo.UpsertLog(context.Background(), 1, time.Now(), 10.0, testUserID)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("got error %v, want %v", err, ErrNotFound)
+		}
+	})
+
+	t.Run("returns error from querier", func(t *testing.T) {
+		mock := &mockQuerier{
+			upsertLogFn: func(ctx context.Context, arg sqlc.UpsertLogParams) (int64, error) {
+				return 0, errors.New("db error")
+			},
+		}
+		repo := &PostgresRepository{q: mock, log: testLogger()}
+
+		err := repo.UpsertLog(context.Background(), 1, time.Now(), 10.0, testUserID)
+		if err == nil {
+			t.Fatal("got nil, want error")
+		}
+	})
+}
+
+func TestRepository_UpsertLogs(t *testing.T) {
+	t.Run("reports per-entry success by matching returned rows", func(t *testing.T) {
+		wantDate := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+		mock := &mockQuerier{
+			upsertLogsFn: func(ctx context.Context, arg sqlc.UpsertLogsParams) ([]sqlc.UpsertLogsRow, error) {
+				return []sqlc.UpsertLogsRow{{HabitID: 1, LogDate: wantDate}}, nil
+			},
+		}
+		repo := &PostgresRepository{q: mock, log: testLogger()}
+
+		ok, err := repo.UpsertLogs(
+			context.Background(),
+			[]int32{1, 2},
+			[]time.Time{wantDate, wantDate},
+			[]float32{10.0, 20.0},
+			testUserID,
+		)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if len(ok) != 2 || !ok[0] || ok[1] {
+			t.Errorf("got %+v, want [true false]", ok)
+		}
 	})
 
 	t.Run("returns error from querier", func(t *testing.T) {
 		mock := &mockQuerier{
-			upsertLogFn: func(ctx context.Context, arg sqlc.UpsertLogParams) error {
-				return errors.New("db error")
+			upsertLogsFn: func(ctx context.Context, arg sqlc.UpsertLogsParams) ([]sqlc.UpsertLogsRow, error) {
+				return nil, errors.New("db error")
 			},
 		}
-		repo := NewRepository(mock)
+		repo := &PostgresRepository{q: mock, log: testLogger()}
 
-		err := repo.UpsertLog(context.Background(), 1, time.Now(), 10.0)
+		_, err := repo.UpsertLogs(context.Background(), []int32{1}, []time.Time{time.Now()}, []float32{10.0}, testUserID)
 		if err == nil {
 			t.Fatal("got nil, want error")
 		}
@@ -137,12 +259,12 @@ func TestRepository_CreateHabit(t *testing.T) {
 
 		mock := &mockQuerier{
 			createHabitFn: func(ctx context.Context, arg sqlc.CreateHabitParams) (sqlc.Habit, error) {
-				return sqlc.Habit{ID: 7, Name: arg.Name, Description: arg.Description}, nil
+				return sqlc.Habit{ID: 7, Name: arg.Name, Description: arg.Description, UserID: arg.UserID}, nil
 			},
 		}
-		repo := NewRepository(mock)
+		repo := &PostgresRepository{q: mock, log: testLogger()}
 
-		got, err := repo.CreateHabit(context.Background(), "Meditation", &desc)
+		got, err := repo.CreateHabit(context.Background(), "Meditation", &desc, testUserID)
 		if err != nil {
 			t.Fatalf("got error %v, want nil", err)
 		}
@@ -163,11 +285,106 @@ func TestRepository_CreateHabit(t *testing.T) {
 				return sqlc.Habit{}, errors.New("unique violation")
 			},
 		}
-		repo := NewRepository(mock)
+		repo := &PostgresRepository{q: mock, log: testLogger()}
 
-		_, err := repo.CreateHabit(context.Background(), "Exercise", nil)
+		_, err := repo.CreateHabit(context.Background(), "Exercise", nil, testUserID)
 		if err == nil {
 			t.Fatal("got nil, want error")
 		}
 	})
 }
+
+func TestRepository_GetHistory(t *testing.T) {
+	t.Run("maps bucket rows to domain types", func(t *testing.T) {
+		day1 := time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC)
+		day2 := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+		mock := &mockQuerier{
+			getHabitHistoryFn: func(ctx context.Context, arg sqlc.GetHabitHistoryParams) ([]sqlc.GetHabitHistoryRow, error) {
+				return []sqlc.GetHabitHistoryRow{
+					{Bucket: day1, Sum: 0, Avg: 0, Count: 0},
+					{Bucket: day2, Sum: 10, Avg: 10, Count: 1},
+				}, nil
+			},
+		}
+		repo := &PostgresRepository{q: mock, log: testLogger()}
+
+		got, err := repo.GetHistory(context.Background(), 1, day1, day2, "day", testUserID)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("got %d buckets, want 2", len(got))
+		}
+		if got[1].Date != "2025-01-31" || got[1].Count != 1 {
+			t.Errorf("got %+v, want date 2025-01-31 count 1", got[1])
+		}
+	})
+
+	t.Run("returns ErrNotFound when no buckets come back", func(t *testing.T) {
+		mock := &mockQuerier{
+			getHabitHistoryFn: func(ctx context.Context, arg sqlc.GetHabitHistoryParams) ([]sqlc.GetHabitHistoryRow, error) {
+				return nil, nil
+			},
+		}
+		repo := &PostgresRepository{q: mock, log: testLogger()}
+
+		_, err := repo.GetHistory(context.Background(), 1, time.Now(), time.Now(), "day", testUserID)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("got error %v, want %v", err, ErrNotFound)
+		}
+	})
+}
+
+func TestRepository_GetStreak(t *testing.T) {
+	t.Run("maps streak row", func(t *testing.T) {
+		mock := &mockQuerier{
+			getHabitStreakFn: func(ctx context.Context, arg sqlc.GetHabitStreakParams) (sqlc.GetHabitStreakRow, error) {
+				return sqlc.GetHabitStreakRow{CurrentStreak: 3, LongestStreak: 7}, nil
+			},
+		}
+		repo := &PostgresRepository{q: mock, log: testLogger()}
+
+		got, err := repo.GetStreak(context.Background(), 1, testUserID)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if got.CurrentStreak != 3 || got.LongestStreak != 7 {
+			t.Errorf("got %+v, want current=3 longest=7", got)
+		}
+	})
+
+	t.Run("returns ErrNotFound when habit doesn't exist", func(t *testing.T) {
+		mock := &mockQuerier{
+			getHabitStreakFn: func(ctx context.Context, arg sqlc.GetHabitStreakParams) (sqlc.GetHabitStreakRow, error) {
+				return sqlc.GetHabitStreakRow{}, pgx.ErrNoRows
+			},
+		}
+		repo := &PostgresRepository{q: mock, log: testLogger()}
+
+		_, err := repo.GetStreak(context.Background(), 1, testUserID)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("got error %v, want %v", err, ErrNotFound)
+		}
+	})
+}
+
+func TestRepository_GetSummary(t *testing.T) {
+	t.Run("maps summary rows", func(t *testing.T) {
+		mock := &mockQuerier{
+			getHabitsSummaryFn: func(ctx context.Context, arg sqlc.GetHabitsSummaryParams) ([]sqlc.GetHabitsSummaryRow, error) {
+				return []sqlc.GetHabitsSummaryRow{
+					{ID: 1, Name: "Exercise", Sum: 100, Avg: 50, Count: 2, CompletionRate: 0.5},
+				}, nil
+			},
+		}
+		repo := &PostgresRepository{q: mock, log: testLogger()}
+
+		got, err := repo.GetSummary(context.Background(), time.Now(), time.Now(), 4, testUserID)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if len(got) != 1 || got[0].HabitID != 1 || got[0].CompletionRate != 0.5 {
+			t.Errorf("got %+v, want 1 summary with HabitID=1 CompletionRate=0.5", got)
+		}
+	})
+}
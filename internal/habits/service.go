@@ -2,18 +2,100 @@ package habits
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"time"
+
+	"gv-api/internal/logger"
 )
 
+// ErrBatchInvalid is returned when one or more entries in a batch log
+// request fail validation; no transaction is opened in this case.
+var ErrBatchInvalid = errors.New("one or more logs failed validation")
+
+// ErrBatchFailed is returned when a batch log transaction was rolled back
+// because one or more entries failed to apply.
+var ErrBatchFailed = errors.New("batch log failed, changes rolled back")
+
+// ErrInvalidRange is returned when a requested date range is backwards or
+// wider than maxRangeDays.
+var ErrInvalidRange = errors.New("invalid date range")
+
+// ErrInvalidGranularity is returned for a history granularity other than
+// day, week, or month.
+var ErrInvalidGranularity = errors.New("invalid granularity")
+
+// ErrInvalidAgg is returned for a history agg other than sum, avg, min,
+// max, or count.
+var ErrInvalidAgg = errors.New("invalid agg")
+
+// maxRangeDays caps how wide an analytics date range can be, so a single
+// request can't trigger an expensive full-table scan.
+const maxRangeDays = 366
+
+var validGranularities = map[string]bool{"day": true, "week": true, "month": true}
+
+var validAggs = map[string]bool{"sum": true, "avg": true, "min": true, "max": true, "count": true}
+
+// toHistoryPoints projects each bucket down to the single aggregate named by
+// agg, for clients that want a plain (date, value) time series rather than
+// every aggregate per bucket.
+func toHistoryPoints(buckets []HistoryBucket, agg string) ([]HistoryPoint, error) {
+	if !validAggs[agg] {
+		return nil, ErrInvalidAgg
+	}
+
+	points := make([]HistoryPoint, len(buckets))
+	for i, b := range buckets {
+		var value float32
+		switch agg {
+		case "sum":
+			value = b.Sum
+		case "avg":
+			value = b.Avg
+		case "min":
+			value = b.Min
+		case "max":
+			value = b.Max
+		case "count":
+			value = float32(b.Count)
+		}
+		points[i] = HistoryPoint{Date: b.Date, Value: value}
+	}
+	return points, nil
+}
+
+// parseRange parses and validates a from/to pair of "2006-01-02" dates,
+// ensuring from <= to and the span doesn't exceed maxRangeDays.
+func parseRange(fromStr, toStr string) (time.Time, time.Time, error) {
+	from, err := time.Parse("2006-01-02", fromStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidRange
+	}
+	to, err := time.Parse("2006-01-02", toStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidRange
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, ErrInvalidRange
+	}
+	if to.Sub(from) > maxRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, ErrInvalidRange
+	}
+	return from, to, nil
+}
+
 type Service struct {
 	repo Repository
+	log  *slog.Logger
 }
 
-func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+func NewService(repo Repository, log *slog.Logger) *Service {
+	return &Service{repo: repo, log: log}
 }
 
-func (s *Service) GetDailyView(ctx context.Context, dateStr string) ([]HabitWithLog, error) {
+func (s *Service) GetDailyView(ctx context.Context, dateStr string, userID int32) ([]HabitWithLog, error) {
 	targetDate := time.Now()
 
 	if dateStr != "" {
@@ -26,17 +108,157 @@ func (s *Service) GetDailyView(ctx context.Context, dateStr string) ([]HabitWith
 
 	targetDate = time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), 0, 0, 0, 0, time.UTC)
 
-	return s.repo.GetHabitsWithLogs(ctx, targetDate)
+	habits, err := s.repo.GetHabitsWithLogs(ctx, targetDate, userID)
+	if err != nil {
+		return nil, err
+	}
+	return habits, nil
 }
 
-func (s *Service) LogHabit(ctx context.Context, req LogUpsertRequest) error {
+func (s *Service) LogHabit(ctx context.Context, req LogUpsertRequest, userID int32) error {
 	date, err := time.Parse("2006-01-02", req.Date)
 	if err != nil {
 		return err
 	}
-	return s.repo.UpsertLog(ctx, req.HabitID, date, req.Value)
+	return s.repo.UpsertLog(ctx, req.HabitID, date, req.Value, userID)
+}
+
+// LogHabitsBatch validates every entry up front (date parse, positive
+// value, no two entries targeting the same habit/date) and, if the whole
+// batch is valid, applies all upserts in a single round trip via
+// Repository.UpsertLogs inside one transaction. Intra-batch duplicates are
+// rejected rather than sent to UpsertLogs, since the underlying upsert
+// applies every row in one statement and Postgres errors if the same
+// (habit_id, log_date) is affected twice. Any entry that doesn't belong to
+// userID rolls back the whole batch. The returned results hold one entry
+// per request, by index; when validation fails, only the entries that
+// failed it carry a status and the others have an empty status.
+func (s *Service) LogHabitsBatch(ctx context.Context, reqs []LogUpsertRequest, userID int32) ([]BatchLogResult, error) {
+	results := make([]BatchLogResult, len(reqs))
+	habitIDs := make([]int32, len(reqs))
+	dates := make([]time.Time, len(reqs))
+	values := make([]float32, len(reqs))
+	invalid := false
+
+	firstSeen := make(map[string]int, len(reqs))
+	for i, req := range reqs {
+		switch date, err := time.Parse("2006-01-02", req.Date); {
+		case err != nil:
+			results[i] = BatchLogResult{Index: i, Status: "invalid", Error: "invalid date"}
+			invalid = true
+		case req.Value <= 0:
+			results[i] = BatchLogResult{Index: i, Status: "invalid", Error: "value must be positive"}
+			invalid = true
+		default:
+			key := fmt.Sprintf("%d|%s", req.HabitID, date.Format("2006-01-02"))
+			if first, dup := firstSeen[key]; dup {
+				if results[first].Status == "" {
+					results[first] = BatchLogResult{Index: first, Status: "invalid", Error: "duplicate habit/date in batch"}
+				}
+				results[i] = BatchLogResult{Index: i, Status: "invalid", Error: "duplicate habit/date in batch"}
+				invalid = true
+				continue
+			}
+			firstSeen[key] = i
+			results[i] = BatchLogResult{Index: i}
+			habitIDs[i] = req.HabitID
+			dates[i] = date
+			values[i] = req.Value
+		}
+	}
+	if invalid {
+		return results, ErrBatchInvalid
+	}
+
+	tx, txRepo, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	oks, err := txRepo.UpsertLogs(ctx, habitIDs, dates, values, userID)
+	if err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			s.log.ErrorContext(ctx, "rollback batch log tx failed", "error", rbErr, "request_id", logger.RequestIDFromContext(ctx))
+		}
+		return nil, err
+	}
+
+	failed := false
+	for i, ok := range oks {
+		if !ok {
+			results[i] = BatchLogResult{Index: i, Status: "error", Error: "habit not found"}
+			failed = true
+			continue
+		}
+		results[i] = BatchLogResult{Index: i, Status: "ok"}
+	}
+
+	if failed {
+		if err := tx.Rollback(ctx); err != nil {
+			s.log.ErrorContext(ctx, "rollback batch log tx failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		}
+		for i := range results {
+			if results[i].Status == "ok" {
+				results[i] = BatchLogResult{Index: i, Status: "rolled_back"}
+			}
+		}
+		return results, ErrBatchFailed
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.log.ErrorContext(ctx, "commit batch log tx failed", "error", err, "request_id", logger.RequestIDFromContext(ctx))
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetHistory returns aggregated log data for a single habit bucketed by
+// granularity (day, week, or month) over [fromStr, toStr].
+func (s *Service) GetHistory(ctx context.Context, habitID int32, fromStr, toStr, granularity string, userID int32) ([]HistoryBucket, error) {
+	if !validGranularities[granularity] {
+		return nil, ErrInvalidGranularity
+	}
+	from, to, err := parseRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := s.repo.GetHistory(ctx, habitID, from, to, granularity, userID)
+	if err != nil {
+		return nil, err
+	}
+	return buckets, nil
 }
 
-func (s *Service) CreateHabit(ctx context.Context, req CreateHabitRequest) (CreateHabitResponse, error) {
-	return s.repo.CreateHabit(ctx, req.Name, req.Description)
+// GetStreak returns the current and longest logging streak for a habit.
+func (s *Service) GetStreak(ctx context.Context, habitID int32, userID int32) (StreakResponse, error) {
+	streak, err := s.repo.GetStreak(ctx, habitID, userID)
+	if err != nil {
+		return StreakResponse{}, err
+	}
+	return streak, nil
+}
+
+// GetSummary returns aggregated log data for every habit belonging to
+// userID over [fromStr, toStr].
+func (s *Service) GetSummary(ctx context.Context, fromStr, toStr string, userID int32) ([]HabitSummary, error) {
+	from, to, err := parseRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+	totalDays := int32(to.Sub(from).Hours()/24) + 1
+
+	summaries, err := s.repo.GetSummary(ctx, from, to, totalDays, userID)
+	if err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func (s *Service) CreateHabit(ctx context.Context, req CreateHabitRequest, userID int32) (CreateHabitResponse, error) {
+	habit, err := s.repo.CreateHabit(ctx, req.Name, req.Description, userID)
+	if err != nil {
+		return CreateHabitResponse{}, err
+	}
+	return habit, nil
 }
@@ -2,60 +2,126 @@ package habits
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 )
 
 type mockRepo struct {
-	getHabitsFn   func(ctx context.Context, date time.Time) ([]HabitWithLog, error)
-	upsertLogFn   func(ctx context.Context, habitID int32, date time.Time, value float32) error
-	createHabitFn func(ctx context.Context, name string, description *string) (CreateHabitResponse, error)
+	getHabitsFn   func(ctx context.Context, date time.Time, userID int32) ([]HabitWithLog, error)
+	upsertLogFn   func(ctx context.Context, habitID int32, date time.Time, value float32, userID int32) error
+	upsertLogsFn  func(ctx context.Context, habitIDs []int32, dates []time.Time, values []float32, userID int32) ([]bool, error)
+	createHabitFn func(ctx context.Context, name string, description *string, userID int32) (CreateHabitResponse, error)
+	beginTxFn     func(ctx context.Context) (Tx, Repository, error)
+	getHistoryFn  func(ctx context.Context, habitID int32, from, to time.Time, granularity string, userID int32) ([]HistoryBucket, error)
+	getStreakFn   func(ctx context.Context, habitID int32, userID int32) (StreakResponse, error)
+	getSummaryFn  func(ctx context.Context, from, to time.Time, totalDays int32, userID int32) ([]HabitSummary, error)
 }
 
-func (m *mockRepo) GetHabitsWithLogs(ctx context.Context, date time.Time) ([]HabitWithLog, error) {
+type mockTx struct {
+	commitFn   func(ctx context.Context) error
+	rollbackFn func(ctx context.Context) error
+}
+
+func (m *mockTx) Commit(ctx context.Context) error {
+	if m.commitFn != nil {
+		return m.commitFn(ctx)
+	}
+	return nil
+}
+
+func (m *mockTx) Rollback(ctx context.Context) error {
+	if m.rollbackFn != nil {
+		return m.rollbackFn(ctx)
+	}
+	return nil
+}
+
+func (m *mockRepo) GetHabitsWithLogs(ctx context.Context, date time.Time, userID int32) ([]HabitWithLog, error) {
 	if m.getHabitsFn != nil {
-		return m.getHabitsFn(ctx, date)
+		return m.getHabitsFn(ctx, date, userID)
 	}
 	return nil, nil
 }
 
-func (m *mockRepo) UpsertLog(ctx context.Context, habitID int32, date time.Time, value float32) error {
+func (m *mockRepo) UpsertLog(ctx context.Context, habitID int32, date time.Time, value float32, userID int32) error {
 	if m.upsertLogFn != nil {
-		return m.upsertLogFn(ctx, habitID, date, value)
+		return m.upsertLogFn(ctx, habitID, date, value, userID)
 	}
 	return nil
 }
 
-func (m *mockRepo) CreateHabit(ctx context.Context, name string, description *string) (CreateHabitResponse, error) {
+func (m *mockRepo) UpsertLogs(ctx context.Context, habitIDs []int32, dates []time.Time, values []float32, userID int32) ([]bool, error) {
+	if m.upsertLogsFn != nil {
+		return m.upsertLogsFn(ctx, habitIDs, dates, values, userID)
+	}
+	ok := make([]bool, len(habitIDs))
+	for i := range ok {
+		ok[i] = true
+	}
+	return ok, nil
+}
+
+func (m *mockRepo) CreateHabit(ctx context.Context, name string, description *string, userID int32) (CreateHabitResponse, error) {
 	if m.createHabitFn != nil {
-		return m.createHabitFn(ctx, name, description)
+		return m.createHabitFn(ctx, name, description, userID)
 	}
 	return CreateHabitResponse{}, nil
 }
 
+func (m *mockRepo) BeginTx(ctx context.Context) (Tx, Repository, error) {
+	if m.beginTxFn != nil {
+		return m.beginTxFn(ctx)
+	}
+	return &mockTx{}, m, nil
+}
+
+func (m *mockRepo) GetHistory(ctx context.Context, habitID int32, from, to time.Time, granularity string, userID int32) ([]HistoryBucket, error) {
+	if m.getHistoryFn != nil {
+		return m.getHistoryFn(ctx, habitID, from, to, granularity, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockRepo) GetStreak(ctx context.Context, habitID int32, userID int32) (StreakResponse, error) {
+	if m.getStreakFn != nil {
+		return m.getStreakFn(ctx, habitID, userID)
+	}
+	return StreakResponse{}, nil
+}
+
+func (m *mockRepo) GetSummary(ctx context.Context, from, to time.Time, totalDays int32, userID int32) ([]HabitSummary, error) {
+	if m.getSummaryFn != nil {
+		return m.getSummaryFn(ctx, from, to, totalDays, userID)
+	}
+	return nil, nil
+}
+
+const testUserID int32 = 42
+
 func TestService_GetDailyView(t *testing.T) {
 	t.Run("parses date correctly", func(t *testing.T) {
 		want := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
 		repo := &mockRepo{
-			getHabitsFn: func(ctx context.Context, got time.Time) ([]HabitWithLog, error) {
+			getHabitsFn: func(ctx context.Context, got time.Time, userID int32) ([]HabitWithLog, error) {
 				if !got.Equal(want) {
 					t.Errorf("got date %v, want %v", got, want)
 				}
 				return []HabitWithLog{}, nil
 			},
 		}
-		svc := NewService(repo)
+		svc := NewService(repo, testLogger())
 
-		_, err := svc.GetDailyView(context.Background(), "2025-01-31")
+		_, err := svc.GetDailyView(context.Background(), "2025-01-31", testUserID)
 		if err != nil {
 			t.Fatalf("got error %v, want nil", err)
 		}
 	})
 
 	t.Run("returns error for invalid date", func(t *testing.T) {
-		svc := NewService(&mockRepo{})
+		svc := NewService(&mockRepo{}, testLogger())
 
-		_, err := svc.GetDailyView(context.Background(), "invalid-date")
+		_, err := svc.GetDailyView(context.Background(), "invalid-date", testUserID)
 		if err == nil {
 			t.Fatal("got nil, want error")
 		}
@@ -63,13 +129,13 @@ func TestService_GetDailyView(t *testing.T) {
 
 	t.Run("returns empty results", func(t *testing.T) {
 		repo := &mockRepo{
-			getHabitsFn: func(ctx context.Context, date time.Time) ([]HabitWithLog, error) {
+			getHabitsFn: func(ctx context.Context, date time.Time, userID int32) ([]HabitWithLog, error) {
 				return []HabitWithLog{}, nil
 			},
 		}
-		svc := NewService(repo)
+		svc := NewService(repo, testLogger())
 
-		got, err := svc.GetDailyView(context.Background(), "2025-01-31")
+		got, err := svc.GetDailyView(context.Background(), "2025-01-31", testUserID)
 		if err != nil {
 			t.Fatalf("got error %v, want nil", err)
 		}
@@ -81,14 +147,14 @@ func TestService_GetDailyView(t *testing.T) {
 	t.Run("uses today when date is empty", func(t *testing.T) {
 		var got time.Time
 		repo := &mockRepo{
-			getHabitsFn: func(ctx context.Context, date time.Time) ([]HabitWithLog, error) {
+			getHabitsFn: func(ctx context.Context, date time.Time, userID int32) ([]HabitWithLog, error) {
 				got = date
 				return []HabitWithLog{}, nil
 			},
 		}
-		svc := NewService(repo)
+		svc := NewService(repo, testLogger())
 
-		_, err := svc.GetDailyView(context.Background(), "")
+		_, err := svc.GetDailyView(context.Background(), "", testUserID)
 		if err != nil {
 			t.Fatalf("got error %v, want nil", err)
 		}
@@ -99,6 +165,25 @@ func TestService_GetDailyView(t *testing.T) {
 			t.Errorf("got date %v, want %v", got, want)
 		}
 	})
+
+	t.Run("scopes query to the requesting user", func(t *testing.T) {
+		var gotUserID int32
+		repo := &mockRepo{
+			getHabitsFn: func(ctx context.Context, date time.Time, userID int32) ([]HabitWithLog, error) {
+				gotUserID = userID
+				return []HabitWithLog{}, nil
+			},
+		}
+		svc := NewService(repo, testLogger())
+
+		_, err := svc.GetDailyView(context.Background(), "2025-01-31", testUserID)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if gotUserID != testUserID {
+			t.Errorf("got userID %d, want %d", gotUserID, testUserID)
+		}
+	})
 }
 
 func TestService_LogHabit(t *testing.T) {
@@ -106,19 +191,21 @@ func TestService_LogHabit(t *testing.T) {
 		var gotHabitID int32
 		var gotDate time.Time
 		var gotValue float32
+		var gotUserID int32
 
 		mock := &mockRepo{
-			upsertLogFn: func(ctx context.Context, habitID int32, date time.Time, value float32) error {
+			upsertLogFn: func(ctx context.Context, habitID int32, date time.Time, value float32, userID int32) error {
 				gotHabitID = habitID
 				gotDate = date
 				gotValue = value
+				gotUserID = userID
 				return nil
 			},
 		}
-		svc := NewService(mock)
+		svc := NewService(mock, testLogger())
 
 		req := LogUpsertRequest{HabitID: 5, Date: "2025-01-31", Value: 100.0}
-		err := svc.LogHabit(context.Background(), req)
+		err := svc.LogHabit(context.Background(), req, testUserID)
 		if err != nil {
 			t.Fatalf("got error %v, want nil", err)
 		}
@@ -133,36 +220,340 @@ func TestService_LogHabit(t *testing.T) {
 		if gotValue != 100.0 {
 			t.Errorf("got value %f, want 100.0", gotValue)
 		}
+		if gotUserID != testUserID {
+			t.Errorf("got userID %d, want %d", gotUserID, testUserID)
+		}
 	})
 
 	t.Run("returns error for invalid date", func(t *testing.T) {
-		svc := NewService(&mockRepo{})
+		svc := NewService(&mockRepo{}, testLogger())
 
 		req := LogUpsertRequest{HabitID: 1, Date: "not-a-date", Value: 10.0}
-		err := svc.LogHabit(context.Background(), req)
+		err := svc.LogHabit(context.Background(), req, testUserID)
 		if err == nil {
 			t.Fatal("got nil, want error")
 		}
 	})
+
+	t.Run("propagates not found without logging as an error", func(t *testing.T) {
+		mock := &mockRepo{
+			upsertLogFn: func(ctx context.Context, habitID int32, date time.Time, value float32, userID int32) error {
+				return ErrNotFound
+			},
+		}
+		svc := NewService(mock, testLogger())
+
+		req := LogUpsertRequest{HabitID: 1, Date: "2025-01-31", Value: 10.0}
+		err := svc.LogHabit(context.Background(), req, testUserID)
+		if err != ErrNotFound {
+			t.Errorf("got error %v, want %v", err, ErrNotFound)
+		}
+	})
+}
+
+func TestService_LogHabitsBatch(t *testing.T) {
+	t.Run("commits all entries when everything succeeds", func(t *testing.T) {
+		var gotHabitIDs []int32
+		repo := &mockRepo{
+			upsertLogsFn: func(ctx context.Context, habitIDs []int32, dates []time.Time, values []float32, userID int32) ([]bool, error) {
+				gotHabitIDs = habitIDs
+				ok := make([]bool, len(habitIDs))
+				for i := range ok {
+					ok[i] = true
+				}
+				return ok, nil
+			},
+		}
+		svc := NewService(repo, testLogger())
+
+		reqs := []LogUpsertRequest{
+			{HabitID: 1, Date: "2025-01-31", Value: 10},
+			{HabitID: 2, Date: "2025-01-31", Value: 20},
+		}
+		results, err := svc.LogHabitsBatch(context.Background(), reqs, testUserID)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if len(gotHabitIDs) != 2 {
+			t.Fatalf("got %d upserts, want 2", len(gotHabitIDs))
+		}
+		for i, r := range results {
+			if r.Status != "ok" {
+				t.Errorf("result %d: got status %q, want ok", i, r.Status)
+			}
+		}
+	})
+
+	t.Run("rejects the whole batch without a transaction when validation fails", func(t *testing.T) {
+		beginTxCalled := false
+		repo := &mockRepo{}
+		repo.beginTxFn = func(ctx context.Context) (Tx, Repository, error) {
+			beginTxCalled = true
+			return &mockTx{}, repo, nil
+		}
+		svc := NewService(repo, testLogger())
+
+		reqs := []LogUpsertRequest{
+			{HabitID: 1, Date: "2025-01-31", Value: 10},
+			{HabitID: 2, Date: "not-a-date", Value: 20},
+			{HabitID: 3, Date: "2025-01-31", Value: -5},
+		}
+		results, err := svc.LogHabitsBatch(context.Background(), reqs, testUserID)
+		if !errors.Is(err, ErrBatchInvalid) {
+			t.Fatalf("got error %v, want ErrBatchInvalid", err)
+		}
+		if beginTxCalled {
+			t.Error("BeginTx should not be called when validation fails")
+		}
+		if results[1].Status != "invalid" || results[2].Status != "invalid" {
+			t.Errorf("got results %+v, want entries 1 and 2 invalid", results)
+		}
+	})
+
+	t.Run("rejects the whole batch without a transaction when an entry is a duplicate habit/date", func(t *testing.T) {
+		beginTxCalled := false
+		repo := &mockRepo{}
+		repo.beginTxFn = func(ctx context.Context) (Tx, Repository, error) {
+			beginTxCalled = true
+			return &mockTx{}, repo, nil
+		}
+		svc := NewService(repo, testLogger())
+
+		reqs := []LogUpsertRequest{
+			{HabitID: 1, Date: "2025-01-31", Value: 10},
+			{HabitID: 2, Date: "2025-01-31", Value: 20},
+			{HabitID: 1, Date: "2025-01-31", Value: 30},
+		}
+		results, err := svc.LogHabitsBatch(context.Background(), reqs, testUserID)
+		if !errors.Is(err, ErrBatchInvalid) {
+			t.Fatalf("got error %v, want ErrBatchInvalid", err)
+		}
+		if beginTxCalled {
+			t.Error("BeginTx should not be called when validation fails")
+		}
+		if results[0].Status != "invalid" || results[2].Status != "invalid" {
+			t.Errorf("got results %+v, want entries 0 and 2 invalid (duplicate habit/date)", results)
+		}
+		if results[1].Status != "" {
+			t.Errorf("got status %q for entry 1, want untouched", results[1].Status)
+		}
+	})
+
+	t.Run("rolls back the transaction when one entry fails", func(t *testing.T) {
+		rolledBack := false
+		committed := false
+		repo := &mockRepo{
+			upsertLogsFn: func(ctx context.Context, habitIDs []int32, dates []time.Time, values []float32, userID int32) ([]bool, error) {
+				ok := make([]bool, len(habitIDs))
+				for i, id := range habitIDs {
+					ok[i] = id != 2
+				}
+				return ok, nil
+			},
+		}
+		repo.beginTxFn = func(ctx context.Context) (Tx, Repository, error) {
+			tx := &mockTx{
+				rollbackFn: func(ctx context.Context) error { rolledBack = true; return nil },
+				commitFn:   func(ctx context.Context) error { committed = true; return nil },
+			}
+			return tx, repo, nil
+		}
+		svc := NewService(repo, testLogger())
+
+		reqs := []LogUpsertRequest{
+			{HabitID: 1, Date: "2025-01-31", Value: 10},
+			{HabitID: 2, Date: "2025-01-31", Value: 20},
+		}
+		results, err := svc.LogHabitsBatch(context.Background(), reqs, testUserID)
+		if !errors.Is(err, ErrBatchFailed) {
+			t.Fatalf("got error %v, want ErrBatchFailed", err)
+		}
+		if !rolledBack {
+			t.Error("expected transaction to be rolled back")
+		}
+		if committed {
+			t.Error("transaction should not be committed when an entry fails")
+		}
+		if results[0].Status != "rolled_back" {
+			t.Errorf("got status %q for entry 0, want rolled_back", results[0].Status)
+		}
+		if results[1].Status != "error" {
+			t.Errorf("got status %q for entry 1, want error", results[1].Status)
+		}
+	})
+}
+
+func TestService_GetHistory(t *testing.T) {
+	t.Run("delegates to repository with parsed range", func(t *testing.T) {
+		var gotFrom, gotTo time.Time
+		var gotGranularity string
+		repo := &mockRepo{
+			getHistoryFn: func(ctx context.Context, habitID int32, from, to time.Time, granularity string, userID int32) ([]HistoryBucket, error) {
+				gotFrom, gotTo, gotGranularity = from, to, granularity
+				return []HistoryBucket{{Date: "2025-01-31", Count: 1}}, nil
+			},
+		}
+		svc := NewService(repo, testLogger())
+
+		got, err := svc.GetHistory(context.Background(), 1, "2025-01-01", "2025-01-31", "week", testUserID)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		wantFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		wantTo := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+		if !gotFrom.Equal(wantFrom) || !gotTo.Equal(wantTo) {
+			t.Errorf("got range %v..%v, want %v..%v", gotFrom, gotTo, wantFrom, wantTo)
+		}
+		if gotGranularity != "week" {
+			t.Errorf("got granularity %q, want week", gotGranularity)
+		}
+		if len(got) != 1 {
+			t.Fatalf("got %d buckets, want 1", len(got))
+		}
+	})
+
+	t.Run("rejects an invalid granularity", func(t *testing.T) {
+		svc := NewService(&mockRepo{}, testLogger())
+
+		_, err := svc.GetHistory(context.Background(), 1, "2025-01-01", "2025-01-31", "year", testUserID)
+		if !errors.Is(err, ErrInvalidGranularity) {
+			t.Errorf("got error %v, want %v", err, ErrInvalidGranularity)
+		}
+	})
+
+	t.Run("rejects a backwards range", func(t *testing.T) {
+		svc := NewService(&mockRepo{}, testLogger())
+
+		_, err := svc.GetHistory(context.Background(), 1, "2025-01-31", "2025-01-01", "day", testUserID)
+		if !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("got error %v, want %v", err, ErrInvalidRange)
+		}
+	})
+
+	t.Run("rejects a range wider than the cap", func(t *testing.T) {
+		svc := NewService(&mockRepo{}, testLogger())
+
+		_, err := svc.GetHistory(context.Background(), 1, "2020-01-01", "2025-01-01", "day", testUserID)
+		if !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("got error %v, want %v", err, ErrInvalidRange)
+		}
+	})
+}
+
+func TestToHistoryPoints(t *testing.T) {
+	buckets := []HistoryBucket{
+		{Date: "2025-01-01", Sum: 10, Avg: 5, Min: 2, Max: 8, Count: 2},
+	}
+
+	t.Run("projects the requested agg", func(t *testing.T) {
+		cases := map[string]float32{"sum": 10, "avg": 5, "min": 2, "max": 8, "count": 2}
+		for agg, want := range cases {
+			got, err := toHistoryPoints(buckets, agg)
+			if err != nil {
+				t.Fatalf("agg %q: got error %v, want nil", agg, err)
+			}
+			if got[0].Value != want {
+				t.Errorf("agg %q: got value %v, want %v", agg, got[0].Value, want)
+			}
+			if got[0].Date != "2025-01-01" {
+				t.Errorf("agg %q: got date %q, want 2025-01-01", agg, got[0].Date)
+			}
+		}
+	})
+
+	t.Run("rejects an unknown agg", func(t *testing.T) {
+		_, err := toHistoryPoints(buckets, "median")
+		if !errors.Is(err, ErrInvalidAgg) {
+			t.Errorf("got error %v, want %v", err, ErrInvalidAgg)
+		}
+	})
+}
+
+func TestService_GetStreak(t *testing.T) {
+	t.Run("delegates to repository", func(t *testing.T) {
+		repo := &mockRepo{
+			getStreakFn: func(ctx context.Context, habitID int32, userID int32) (StreakResponse, error) {
+				return StreakResponse{CurrentStreak: 2, LongestStreak: 5}, nil
+			},
+		}
+		svc := NewService(repo, testLogger())
+
+		got, err := svc.GetStreak(context.Background(), 1, testUserID)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if got.CurrentStreak != 2 || got.LongestStreak != 5 {
+			t.Errorf("got %+v, want current=2 longest=5", got)
+		}
+	})
+
+	t.Run("propagates not found", func(t *testing.T) {
+		repo := &mockRepo{
+			getStreakFn: func(ctx context.Context, habitID int32, userID int32) (StreakResponse, error) {
+				return StreakResponse{}, ErrNotFound
+			},
+		}
+		svc := NewService(repo, testLogger())
+
+		_, err := svc.GetStreak(context.Background(), 1, testUserID)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("got error %v, want %v", err, ErrNotFound)
+		}
+	})
+}
+
+func TestService_GetSummary(t *testing.T) {
+	t.Run("computes total days and delegates to repository", func(t *testing.T) {
+		var gotTotalDays int32
+		repo := &mockRepo{
+			getSummaryFn: func(ctx context.Context, from, to time.Time, totalDays int32, userID int32) ([]HabitSummary, error) {
+				gotTotalDays = totalDays
+				return []HabitSummary{{HabitID: 1}}, nil
+			},
+		}
+		svc := NewService(repo, testLogger())
+
+		got, err := svc.GetSummary(context.Background(), "2025-01-01", "2025-01-31", testUserID)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if gotTotalDays != 31 {
+			t.Errorf("got totalDays %d, want 31", gotTotalDays)
+		}
+		if len(got) != 1 {
+			t.Fatalf("got %d summaries, want 1", len(got))
+		}
+	})
+
+	t.Run("rejects an invalid range", func(t *testing.T) {
+		svc := NewService(&mockRepo{}, testLogger())
+
+		_, err := svc.GetSummary(context.Background(), "not-a-date", "2025-01-31", testUserID)
+		if !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("got error %v, want %v", err, ErrInvalidRange)
+		}
+	})
 }
 
 func TestService_CreateHabit(t *testing.T) {
 	t.Run("delegates to repository", func(t *testing.T) {
 		var gotName string
 		var gotDesc *string
+		var gotUserID int32
 
 		desc := "test description"
 		mock := &mockRepo{
-			createHabitFn: func(ctx context.Context, name string, description *string) (CreateHabitResponse, error) {
+			createHabitFn: func(ctx context.Context, name string, description *string, userID int32) (CreateHabitResponse, error) {
 				gotName = name
 				gotDesc = description
+				gotUserID = userID
 				return CreateHabitResponse{ID: 1, Name: name, Description: description}, nil
 			},
 		}
-		svc := NewService(mock)
+		svc := NewService(mock, testLogger())
 
 		req := CreateHabitRequest{Name: "Exercise", Description: &desc}
-		got, err := svc.CreateHabit(context.Background(), req)
+		got, err := svc.CreateHabit(context.Background(), req, testUserID)
 		if err != nil {
 			t.Fatalf("got error %v, want nil", err)
 		}
@@ -172,6 +563,9 @@ func TestService_CreateHabit(t *testing.T) {
 		if gotDesc == nil || *gotDesc != "test description" {
 			t.Errorf("got description %v, want %q", gotDesc, "test description")
 		}
+		if gotUserID != testUserID {
+			t.Errorf("got userID %d, want %d", gotUserID, testUserID)
+		}
 		if got.ID != 1 {
 			t.Errorf("got ID %d, want 1", got.ID)
 		}
@@ -181,15 +575,15 @@ func TestService_CreateHabit(t *testing.T) {
 		var gotDesc *string
 
 		mock := &mockRepo{
-			createHabitFn: func(ctx context.Context, name string, description *string) (CreateHabitResponse, error) {
+			createHabitFn: func(ctx context.Context, name string, description *string, userID int32) (CreateHabitResponse, error) {
 				gotDesc = description
 				return CreateHabitResponse{ID: 1, Name: name}, nil
 			},
 		}
-		svc := NewService(mock)
+		svc := NewService(mock, testLogger())
 
 		req := CreateHabitRequest{Name: "Exercise", Description: nil}
-		_, err := svc.CreateHabit(context.Background(), req)
+		_, err := svc.CreateHabit(context.Background(), req, testUserID)
 		if err != nil {
 			t.Fatalf("got error %v, want nil", err)
 		}
@@ -0,0 +1,39 @@
+// Package health provides liveness and readiness endpoints for Kubernetes
+// probes.
+package health
+
+import (
+	"context"
+	"net/http"
+
+	"gv-api/internal/response"
+)
+
+// Pinger is the dependency a readiness check needs to confirm connectivity.
+// *pgxpool.Pool satisfies this.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type Handler struct {
+	db Pinger
+}
+
+func NewHandler(db Pinger) *Handler {
+	return &Handler{db: db}
+}
+
+// Healthz -> GET /healthz reports that the process is alive.
+func (h *Handler) Healthz(w http.ResponseWriter, r *http.Request) {
+	response.JSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Readyz -> GET /readyz reports that the process can serve traffic, i.e.
+// the database is reachable.
+func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
+	if err := h.db.Ping(r.Context()); err != nil {
+		response.Error(w, http.StatusServiceUnavailable, "database not reachable")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
@@ -0,0 +1,65 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type mockPinger struct {
+	pingFn func(ctx context.Context) error
+}
+
+func (m *mockPinger) Ping(ctx context.Context) error {
+	if m.pingFn != nil {
+		return m.pingFn(ctx)
+	}
+	return nil
+}
+
+func TestHandler_Healthz(t *testing.T) {
+	t.Run("always returns 200", func(t *testing.T) {
+		handler := NewHandler(&mockPinger{})
+
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		rec := httptest.NewRecorder()
+
+		handler.Healthz(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusOK)
+		}
+	})
+}
+
+func TestHandler_Readyz(t *testing.T) {
+	t.Run("returns 200 when the database is reachable", func(t *testing.T) {
+		handler := NewHandler(&mockPinger{})
+
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		rec := httptest.NewRecorder()
+
+		handler.Readyz(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("returns 503 when the database ping fails", func(t *testing.T) {
+		handler := NewHandler(&mockPinger{
+			pingFn: func(ctx context.Context) error { return errors.New("connection refused") },
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		rec := httptest.NewRecorder()
+
+		handler.Readyz(rec, req)
+
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusServiceUnavailable)
+		}
+	})
+}
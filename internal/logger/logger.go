@@ -0,0 +1,36 @@
+// Package logger provides structured logging for the API.
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"gv-api/internal/config"
+)
+
+type ctxKey string
+
+const requestIDKey ctxKey = "request_id"
+
+// Setup builds and installs the process-wide structured logger based on cfg.
+// The returned logger writes JSON to stdout at the configured level.
+func Setup(cfg *config.Config) *slog.Logger {
+	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: cfg.LogLevel,
+	})
+	logger := slog.New(handler)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// WithRequestID returns a copy of ctx carrying requestID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or "" if none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
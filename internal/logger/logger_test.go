@@ -0,0 +1,24 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithRequestID(t *testing.T) {
+	t.Run("round trips through context", func(t *testing.T) {
+		ctx := WithRequestID(context.Background(), "abc123")
+
+		got := RequestIDFromContext(ctx)
+		if got != "abc123" {
+			t.Errorf("got %q, want %q", got, "abc123")
+		}
+	})
+
+	t.Run("returns empty string when absent", func(t *testing.T) {
+		got := RequestIDFromContext(context.Background())
+		if got != "" {
+			t.Errorf("got %q, want empty string", got)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Run("injects a request ID and forwards to the handler", func(t *testing.T) {
+		var gotRequestID string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotRequestID = RequestIDFromContext(r.Context())
+			w.WriteHeader(http.StatusTeapot)
+		})
+
+		logger := slog.New(slog.NewTextHandler(httptest.NewRecorder(), nil))
+		handler := Middleware(logger)(next)
+
+		req := httptest.NewRequest(http.MethodGet, "/habits", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusTeapot {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusTeapot)
+		}
+		if gotRequestID == "" {
+			t.Error("got empty request ID, want one injected into context")
+		}
+	})
+}
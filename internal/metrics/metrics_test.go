@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Run("labels metrics with the chi route pattern, not the raw path", func(t *testing.T) {
+		r := chi.NewRouter()
+		r.Use(Middleware)
+		r.Get("/habits/{id}/history", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/habits/42/history", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		req2 := httptest.NewRequest(http.MethodGet, "/habits/99/history", nil)
+		rec2 := httptest.NewRecorder()
+		r.ServeHTTP(rec2, req2)
+
+		got := testutil.ToFloat64(httpRequestsTotal.WithLabelValues(http.MethodGet, "/habits/{id}/history", "200"))
+		if got != 2 {
+			t.Errorf("got %v requests labeled with the route pattern, want 2", got)
+		}
+	})
+}
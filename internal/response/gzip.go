@@ -0,0 +1,94 @@
+package response
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// minGzipSize is the smallest response body worth paying gzip's header and
+// trailer overhead for; smaller bodies are sent uncompressed.
+const minGzipSize = 1024
+
+// Gzip negotiates compression via the request's Accept-Encoding header. When
+// the client advertises gzip support, writes are buffered until they reach
+// minGzipSize, at which point the response switches to a gzip.Writer with
+// Content-Encoding: gzip. Responses that never reach the threshold are sent
+// uncompressed as normal. Vary: Accept-Encoding is always set so caches don't
+// serve a compressed response to a client that can't decode it.
+func Gzip(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Vary", "Accept-Encoding")
+
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.Close()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// gzipResponseWriter buffers writes below minGzipSize so it can decide, once
+// it knows how large the body actually is, whether to gzip it or flush it
+// through unmodified.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz     *gzip.Writer
+	status int
+	buf    []byte
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+	if len(w.buf) < minGzipSize {
+		return len(b), nil
+	}
+	return w.startGzip()
+}
+
+func (w *gzipResponseWriter) startGzip() (int, error) {
+	w.ResponseWriter.Header().Set("Content-Encoding", "gzip")
+	w.ResponseWriter.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.statusOrOK())
+
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+	buffered := w.buf
+	w.buf = nil
+	if _, err := w.gz.Write(buffered); err != nil {
+		return 0, err
+	}
+	return len(buffered), nil
+}
+
+func (w *gzipResponseWriter) statusOrOK() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// Close flushes a buffered body that never crossed minGzipSize, or closes
+// the gzip stream for one that did.
+func (w *gzipResponseWriter) Close() error {
+	if w.gz != nil {
+		return w.gz.Close()
+	}
+
+	w.ResponseWriter.WriteHeader(w.statusOrOK())
+	if w.buf == nil {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf)
+	return err
+}
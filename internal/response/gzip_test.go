@@ -0,0 +1,123 @@
+package response
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type widget struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestGzip(t *testing.T) {
+	t.Run("compresses a large JSON body for a gzip client", func(t *testing.T) {
+		data := widget{ID: 1, Name: strings.Repeat("a", minGzipSize)}
+
+		handler := Gzip(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			JSON(w, http.StatusOK, data)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Content-Encoding"); got != "gzip" {
+			t.Errorf("Content-Encoding = %q, want %q", got, "gzip")
+		}
+		if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+			t.Errorf("Vary = %q, want %q", got, "Accept-Encoding")
+		}
+
+		gr, err := gzip.NewReader(rec.Body)
+		if err != nil {
+			t.Fatalf("gzip.NewReader() error = %v", err)
+		}
+		decoded, err := io.ReadAll(gr)
+		if err != nil {
+			t.Fatalf("read gzip body error = %v", err)
+		}
+
+		var got widget
+		if err := json.Unmarshal(decoded, &got); err != nil {
+			t.Fatalf("unmarshal decoded body error = %v", err)
+		}
+		if got != data {
+			t.Errorf("got %+v, want %+v", got, data)
+		}
+	})
+
+	t.Run("leaves the body plain for a non-gzip client", func(t *testing.T) {
+		data := widget{ID: 1, Name: strings.Repeat("a", minGzipSize)}
+
+		handler := Gzip(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			JSON(w, http.StatusOK, data)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Content-Encoding"); got != "" {
+			t.Errorf("Content-Encoding = %q, want empty", got)
+		}
+
+		var got widget
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("unmarshal body error = %v", err)
+		}
+		if got != data {
+			t.Errorf("got %+v, want %+v", got, data)
+		}
+	})
+
+	t.Run("skips compression for a body under the threshold", func(t *testing.T) {
+		data := widget{ID: 1, Name: "small"}
+
+		handler := Gzip(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			JSON(w, http.StatusOK, data)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Content-Encoding"); got != "" {
+			t.Errorf("Content-Encoding = %q, want empty for a small body", got)
+		}
+
+		var got widget
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("unmarshal body error = %v", err)
+		}
+		if got != data {
+			t.Errorf("got %+v, want %+v", got, data)
+		}
+	})
+
+	t.Run("preserves the status code", func(t *testing.T) {
+		handler := Gzip(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Error(w, http.StatusNotFound, "not found")
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+}
@@ -34,10 +34,13 @@ type LogRequest struct {
 	Value   float32 `json:"value"`
 }
 
-// APIClient is a test driver that wraps HTTP calls to the API.
+// APIClient is a test driver that wraps HTTP calls to the API. /habits*
+// requires a bearer token, so callers must call Login before using the
+// other methods.
 type APIClient struct {
 	baseURL string
 	http    *http.Client
+	token   string
 }
 
 func NewAPIClient(t *testing.T) *APIClient {
@@ -48,11 +51,59 @@ func NewAPIClient(t *testing.T) *APIClient {
 	}
 }
 
+// Login registers email/password, tolerating an already-registered email
+// so tests can reuse the same account across runs, then logs in and stores
+// the resulting access token for c to attach to subsequent requests.
+func (c *APIClient) Login(t *testing.T, email, password string) {
+	t.Helper()
+
+	registerBody, _ := json.Marshal(map[string]string{"email": email, "password": password})
+	resp, err := c.http.Post(c.baseURL+"/auth/register", "application/json", bytes.NewBuffer(registerBody))
+	if err != nil {
+		t.Fatalf("failed to register: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
+		t.Fatalf("got status %d registering, want 201 or 409", resp.StatusCode)
+	}
+
+	loginBody, _ := json.Marshal(map[string]string{"email": email, "password": password})
+	resp, err = c.http.Post(c.baseURL+"/auth/login", "application/json", bytes.NewBuffer(loginBody))
+	if err != nil {
+		t.Fatalf("failed to log in: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d logging in, want 200", resp.StatusCode)
+	}
+
+	var tokens struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		t.Fatalf("failed to decode login response: %v", err)
+	}
+	c.token = tokens.AccessToken
+}
+
+// authedRequest builds a request with a JSON body and the client's bearer
+// token attached.
+func (c *APIClient) authedRequest(t *testing.T, method, url string, body []byte) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	return req
+}
+
 func (c *APIClient) CreateHabit(t *testing.T, req CreateHabitRequest) CreateHabitResponse {
 	t.Helper()
 	body, _ := json.Marshal(req)
 
-	resp, err := c.http.Post(c.baseURL+"/habits", "application/json", bytes.NewBuffer(body))
+	resp, err := c.http.Do(c.authedRequest(t, http.MethodPost, c.baseURL+"/habits", body))
 	if err != nil {
 		t.Fatalf("failed to create habit: %v", err)
 	}
@@ -73,7 +124,7 @@ func (c *APIClient) LogHabit(t *testing.T, req LogRequest) {
 	t.Helper()
 	body, _ := json.Marshal(req)
 
-	resp, err := c.http.Post(c.baseURL+"/habits/log", "application/json", bytes.NewBuffer(body))
+	resp, err := c.http.Do(c.authedRequest(t, http.MethodPost, c.baseURL+"/habits/log", body))
 	if err != nil {
 		t.Fatalf("failed to log habit: %v", err)
 	}
@@ -87,7 +138,8 @@ func (c *APIClient) LogHabit(t *testing.T, req LogRequest) {
 func (c *APIClient) GetDailyView(t *testing.T, date string) []HabitWithLog {
 	t.Helper()
 
-	resp, err := c.http.Get(fmt.Sprintf("%s/habits?date=%s", c.baseURL, date))
+	url := fmt.Sprintf("%s/habits?date=%s", c.baseURL, date)
+	resp, err := c.http.Do(c.authedRequest(t, http.MethodGet, url, nil))
 	if err != nil {
 		t.Fatalf("failed to get daily view: %v", err)
 	}
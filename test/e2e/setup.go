@@ -35,7 +35,7 @@ func truncateTables(t *testing.T) {
 	}
 	defer conn.Close(ctx)
 
-	_, err = conn.Exec(ctx, "TRUNCATE habits, habit_logs CASCADE")
+	_, err = conn.Exec(ctx, "TRUNCATE users, habits, habit_logs CASCADE")
 	if err != nil {
 		t.Fatalf("failed to truncate tables: %v", err)
 	}